@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type apiClient struct {
@@ -13,15 +14,47 @@ type apiClient struct {
 	apiHost    string
 	apiKey     string
 	debug      bool
+	// cache is nil when caching is disabled
+	cache Cache
+	// flights is nil when request coalescing is disabled
+	flights *flightGroup
+	// breaker is nil when the circuit breaker is disabled
+	breaker *circuitBreaker
+	// timeout is the default timeout of the operations, it's disabled when 0
+	timeout           time.Duration
+	operationTimeouts map[Operation]time.Duration
+	idempotencyStore  IdempotencyStore
+	uploadMode        UploadMode
+	// validationDisabled skips validating the params before sending the requests
+	validationDisabled bool
+	// personLimits is the limits of the person to validate, DefaultPersonLimits when nil
+	personLimits *PersonLimits
 }
 
-func newAPIClient(httpClient *http.Client, apiHost string, apiKey string, debug bool) *apiClient {
-	return &apiClient{
-		apiKey:     apiKey,
-		httpClient: httpClient,
-		apiHost:    apiHost,
-		debug:      debug,
+func newAPIClient(opts *ClientOptions) *apiClient {
+	a := &apiClient{
+		apiKey:             opts.APIKey,
+		httpClient:         opts.HttpClient,
+		apiHost:            opts.APIHost,
+		debug:              opts.Debug,
+		cache:              opts.Cache,
+		timeout:            opts.Timeout,
+		operationTimeouts:  opts.OperationTimeouts,
+		idempotencyStore:   opts.IdempotencyStore,
+		uploadMode:         opts.UploadMode,
+		validationDisabled: opts.ValidationDisabled,
+		personLimits:       opts.PersonLimits,
 	}
+	if a.idempotencyStore == nil {
+		a.idempotencyStore = NewMemoryIdempotencyStore()
+	}
+	if !opts.RequestCoalescingDisabled {
+		a.flights = newFlightGroup()
+	}
+	if opts.CircuitBreaker != nil {
+		a.breaker = newCircuitBreaker(opts.CircuitBreaker)
+	}
+	return a
 }
 
 func (a *apiClient) request(req *http.Request, v any) error {
@@ -29,28 +62,159 @@ func (a *apiClient) request(req *http.Request, v any) error {
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
-	if resp.StatusCode >= 400 {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err == nil {
-			return fmt.Errorf("failed to send request status: %s, body: %s", resp.Status, string(bodyBytes))
-		}
-		return fmt.Errorf("failed to send request: %s", resp.Status)
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
 	}
 
+	// v is nil when the response has no content
+	if v == nil {
+		return nil
+	}
 	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", timeoutErr(req.Context(), err))
+	}
+	return nil
+}
+
+// requestCached makes the GET request of the resource cached with key, sharing the response with the identical requests in flight.
+// The cached response is revalidated with a conditional request when it has validators, otherwise it's used as is.
+// The response is cached only when isTerminal reports the decoded v is in the terminal state.
+func (a *apiClient) requestCached(req *http.Request, key string, v any, isTerminal func() bool) error {
+	var (
+		cached *CacheEntry
+		ok     bool
+	)
+	if a.cache != nil {
+		cached, ok = a.cache.Get(key)
+	}
+	if ok && cached.ETag == "" && cached.LastModified == "" {
+		if err := json.Unmarshal(cached.Body, v); err != nil {
+			return fmt.Errorf("failed to decode cached response body: %w", err)
+		}
+		return nil
+	}
+	if ok {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := a.doCoalesced(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if ok && resp.StatusCode == http.StatusNotModified {
+		if err := json.Unmarshal(cached.Body, v); err != nil {
+			return fmt.Errorf("failed to decode cached response body: %w", err)
+		}
+		return nil
+	}
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", timeoutErr(req.Context(), err))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
 		return fmt.Errorf("failed to decode response body: %w", err)
 	}
+	if a.cache == nil {
+		return nil
+	}
+	if isTerminal() {
+		a.cache.Set(key, &CacheEntry{
+			Body:         body,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		})
+	} else if ok {
+		a.cache.Delete(key)
+	}
 	return nil
 }
 
+// invalidate removes the cached resource, it's called when the resource is changed by the client.
+func (a *apiClient) invalidate(key string) {
+	if a.cache != nil {
+		a.cache.Delete(key)
+	}
+}
+
+// APIError is the error response of the API.
+type APIError struct {
+	StatusCode int
+	Status     string
+	// Body is the response body, it's empty when it couldn't be read
+	Body string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("failed to send request: %s", e.Status)
+	}
+	return fmt.Sprintf("failed to send request status: %s, body: %s", e.Status, e.Body)
+}
+
+// IsPermanent reports whether retrying the same request fails again, e.g. 400 Bad Request.
+// Timeouts, conflicts, rate limits and server errors are not permanent.
+func (e *APIError) IsPermanent() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	}
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	return newAPIError(resp)
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+	if bodyBytes, err := io.ReadAll(resp.Body); err == nil {
+		e.Body = string(bodyBytes)
+	}
+	return e
+}
+
 func (a *apiClient) do(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "APIKey "+a.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if a.breaker == nil {
+		resp, err := a.httpClient.Do(req)
+		return resp, timeoutErr(req.Context(), err)
+	}
+
+	probe, err := a.breaker.allow()
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
 	resp, err := a.httpClient.Do(req)
-	return resp, err
+	a.breaker.record(probe, circuitResultOf(req, resp, err))
+	return resp, timeoutErr(req.Context(), err)
 }
 
 func (a *apiClient) buildURL(path string) (*url.URL, error) {
 	u, err := url.Parse(a.apiHost + path)
 	return u, err
 }
+
+// closeBody closes the body of the request which is not sent, e.g. to stop the goroutine streaming the multipart body.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
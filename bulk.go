@@ -0,0 +1,101 @@
+package saia
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BulkResult is the result of the operation for each item of a bulk operation.
+type BulkResult struct {
+	ID  int
+	Err error
+}
+
+// bulk calls f for each id and returns the results in the order of ids.
+// The failure of an item doesn't stop the operation for the rest of items.
+func bulk(ctx context.Context, ids []int, f func(ctx context.Context, id int) error) []*BulkResult {
+	results := make([]*BulkResult, 0, len(ids))
+	for _, id := range ids {
+		results = append(results, &BulkResult{ID: id, Err: f(ctx, id)})
+	}
+	return results
+}
+
+// BatchOptions is the options of the batch fetch operations.
+type BatchOptions struct {
+	// Concurrency is the max number of requests in flight
+	Concurrency int
+	// RateLimit is the max number of requests started per second, it's unlimited when 0
+	RateLimit float64
+}
+
+func newDefaultBatchOptions() *BatchOptions {
+	return &BatchOptions{
+		Concurrency: 8,
+	}
+}
+
+type BatchOption func(*BatchOptions)
+
+func BatchOptionConcurrency(concurrency int) BatchOption {
+	return func(o *BatchOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// BatchOptionRateLimit limits the requests to requestsPerSecond to stay within the rate limit of the API key.
+func BatchOptionRateLimit(requestsPerSecond float64) BatchOption {
+	return func(o *BatchOptions) {
+		o.RateLimit = requestsPerSecond
+	}
+}
+
+// batch calls f for each id concurrently and returns the values and errors in the order of ids.
+// The failure of an item doesn't stop the operation for the rest of items.
+func batch[T any](ctx context.Context, ids []int, opt []BatchOption, f func(ctx context.Context, id int) (T, error)) ([]T, []error) {
+	opts := newDefaultBatchOptions()
+	for _, o := range opt {
+		o(opts)
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	var tick <-chan time.Time
+	if opts.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RateLimit))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	values := make([]T, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		// the first request is not delayed by the rate limit
+		if tick != nil && i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-tick:
+			}
+		}
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			values[i], errs[i] = f(ctx, id)
+		}(i, id)
+	}
+	wg.Wait()
+	return values, errs
+}
@@ -0,0 +1,119 @@
+package saia
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// CacheEntry is a cached response body with its validators for the conditional requests.
+type CacheEntry struct {
+	Body []byte
+	// ETag and LastModified are the validators returned by the server, they are empty when the server doesn't support them
+	ETag         string
+	LastModified string
+}
+
+// Cache stores the responses of the persons and measurements in the terminal states.
+// It must be safe for concurrent use. Don't share a cache between clients of different API keys.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// LRUCache is an in-memory Cache which evicts the least recently used entry when it's full.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCache creates a new LRUCache which holds up to size entries.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		ll:      list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *LRUCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.(*lruItem).entry, true
+}
+
+func (c *LRUCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruItem).entry = entry
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.ll.PushFront(&lruItem{key: key, entry: entry})
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.ll.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of the cached entries.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func personCacheKey(personID int) string {
+	return fmt.Sprintf("persons/%d", personID)
+}
+
+func measurementCacheKey(measurementID int) string {
+	return fmt.Sprintf("measurements/%d", measurementID)
+}
+
+// isTerminal reports whether the person won't change by the calculation anymore.
+func (p *Person) isTerminal() bool {
+	return p.TaskSet.IsReady && p.TaskSet.IsSuccessful
+}
+
+// isTerminal reports whether the measurement won't change by the measuring process anymore.
+func (m *Measurement) isTerminal() bool {
+	return m.Status != MeasurementStatusPending && m.Status != ""
+}
+
+// InvalidatePerson removes the cached person, e.g. when it's updated by another client.
+func (c *Client) InvalidatePerson(personID int) {
+	c.apiClient.invalidate(personCacheKey(personID))
+}
+
+// InvalidateMeasurement removes the cached measurement, e.g. when it's updated by another client.
+func (c *Client) InvalidateMeasurement(measurementID int) {
+	c.apiClient.invalidate(measurementCacheKey(measurementID))
+}
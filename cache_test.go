@@ -0,0 +1,143 @@
+package saia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	c := NewLRUCache(2)
+	c.Set("a", &CacheEntry{Body: []byte("a")})
+	c.Set("b", &CacheEntry{Body: []byte("b")})
+	// a is used recently, so b is evicted
+	c.Get("a")
+	c.Set("c", &CacheEntry{Body: []byte("c")})
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%q) ok = %v, want %v", key, ok, want)
+		}
+	}
+	c.Delete("a")
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+}
+
+func Test_personAPI_GetPerson_Cache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		resp         string
+		etag         string
+		wantRequests int32
+		wantRevalid  int32
+	}{
+		{
+			name:         "Successful person without validators",
+			resp:         `{"id": 1, "task_set": {"is_ready": true, "is_successful": true}}`,
+			wantRequests: 1,
+		},
+		{
+			name:         "Successful person with ETag",
+			resp:         `{"id": 1, "task_set": {"is_ready": true, "is_successful": true}}`,
+			etag:         `"v1"`,
+			wantRequests: 3,
+			wantRevalid:  2,
+		},
+		{
+			name:         "Pending person",
+			resp:         `{"id": 1, "task_set": {"is_ready": false}}`,
+			wantRequests: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests, revalidated int32
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+					if r.Header.Get("If-None-Match") == tt.etag {
+						atomic.AddInt32(&revalidated, 1)
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+				}
+				fmt.Fprintln(w, tt.resp)
+			})
+			s := httptest.NewServer(h)
+			defer s.Close()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, cache: NewLRUCache(10)}}
+
+			for i := 0; i < 3; i++ {
+				person, err := m.GetPerson(context.Background(), 1)
+				if err != nil {
+					t.Fatalf("GetPerson() error = %v", err)
+				}
+				if person.ID != 1 {
+					t.Errorf("GetPerson() id = %d, want 1", person.ID)
+				}
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("GetPerson() sent %d requests, want %d", requests, tt.wantRequests)
+			}
+			if revalidated != tt.wantRevalid {
+				t.Errorf("GetPerson() revalidated %d times, want %d", revalidated, tt.wantRevalid)
+			}
+		})
+	}
+}
+
+func Test_measurementAPI_GetMeasurement_Invalidation(t *testing.T) {
+	t.Parallel()
+
+	var gets int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&gets, 1)
+		}
+		fmt.Fprintln(w, `{"id": 1, "status": "success"}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	cache := NewLRUCache(10)
+	m := &measurementAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, cache: cache}}
+	ctx := context.Background()
+
+	if _, err := m.GetMeasurement(ctx, 1); err != nil {
+		t.Fatalf("GetMeasurement() error = %v", err)
+	}
+	if _, err := m.GetMeasurement(ctx, 1); err != nil {
+		t.Fatalf("GetMeasurement() error = %v", err)
+	}
+	if gets != 1 {
+		t.Fatalf("GetMeasurement() sent %d requests, want 1", gets)
+	}
+
+	if _, err := m.ArchiveMeasurement(ctx, 1); err != nil {
+		t.Fatalf("ArchiveMeasurement() error = %v", err)
+	}
+	if _, err := m.GetMeasurement(ctx, 1); err != nil {
+		t.Fatalf("GetMeasurement() error = %v", err)
+	}
+	if gets != 2 {
+		t.Errorf("GetMeasurement() after update sent %d requests, want 2", gets)
+	}
+
+	c := &Client{apiClient: m.apiClient}
+	c.InvalidateMeasurement(1)
+	if _, ok := cache.Get(measurementCacheKey(1)); ok {
+		t.Errorf("InvalidateMeasurement() didn't remove the cached measurement")
+	}
+}
@@ -0,0 +1,134 @@
+package saia
+
+//go:generate go run ./internal/cmd/gencatalog -o measurement_catalog_gen.go
+
+// MeasurementKey identifies a measurement by its group and JSON key, e.g. "volume.waist".
+type MeasurementKey string
+
+// MeasurementGroup is the params group which the measurement belongs to.
+type MeasurementGroup string
+
+const (
+	MeasurementGroupFront  MeasurementGroup = "front"
+	MeasurementGroupSide   MeasurementGroup = "side"
+	MeasurementGroupVolume MeasurementGroup = "volume"
+)
+
+// MeasurementKind is the category of the measurement.
+type MeasurementKind string
+
+const (
+	MeasurementKindGirth      MeasurementKind = "girth"
+	MeasurementKindLength     MeasurementKind = "length"
+	MeasurementKindHeight     MeasurementKind = "height"
+	MeasurementKindWidth      MeasurementKind = "width"
+	MeasurementKindAngle      MeasurementKind = "angle"
+	MeasurementKindPercentage MeasurementKind = "percentage"
+)
+
+// Unit returns the unit of the values of the kind.
+func (k MeasurementKind) Unit() string {
+	switch k {
+	case MeasurementKindAngle:
+		return "deg"
+	case MeasurementKindPercentage:
+		return "%"
+	default:
+		return "cm"
+	}
+}
+
+// IsLength reports whether the values of the kind are lengths in cm.
+func (k MeasurementKind) IsLength() bool {
+	return k.Unit() == "cm"
+}
+
+// MeasurementInfo is the metadata of a measurement.
+type MeasurementInfo struct {
+	Key         MeasurementKey
+	JSONKey     string
+	Group       MeasurementGroup
+	Kind        MeasurementKind
+	Name        string
+	Description string
+}
+
+// MeasurementCatalog returns the metadata of all the measurements in the order of the params structs.
+func MeasurementCatalog() []*MeasurementInfo {
+	catalog := make([]*MeasurementInfo, len(measurementCatalog))
+	for i, info := range measurementCatalog {
+		info := *info
+		catalog[i] = &info
+	}
+	return catalog
+}
+
+var measurementCatalogByKey = func() map[MeasurementKey]*MeasurementInfo {
+	m := make(map[MeasurementKey]*MeasurementInfo, len(measurementCatalog))
+	for _, info := range measurementCatalog {
+		m[info.Key] = info
+	}
+	return m
+}()
+
+// LookupMeasurement returns the metadata of the measurement.
+func LookupMeasurement(key MeasurementKey) (*MeasurementInfo, bool) {
+	info, ok := measurementCatalogByKey[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *info
+	return &copied, true
+}
+
+// Measurements returns the values of all the calculated measurements of the person.
+func (p *Person) Measurements() map[MeasurementKey]float64 {
+	return measurementValues(p.FrontParams, p.SideParams, p.VolumeParams)
+}
+
+// Get returns the value of the measurement, it returns false when the measurement is not calculated.
+func (p *Person) Get(key MeasurementKey) (float64, bool) {
+	return getMeasurement(p.FrontParams, p.SideParams, p.VolumeParams, key)
+}
+
+// Measurements returns the values of all the calculated measurements of the task set.
+func (t *PersonTaskSet) Measurements() map[MeasurementKey]float64 {
+	return measurementValues(t.FrontParams, t.SideParams, t.VolumeParams)
+}
+
+// Get returns the value of the measurement, it returns false when the measurement is not calculated.
+func (t *PersonTaskSet) Get(key MeasurementKey) (float64, bool) {
+	return getMeasurement(t.FrontParams, t.SideParams, t.VolumeParams, key)
+}
+
+func measurementValues(front *FrontParams, side *SideParams, volume *VolumeParams) map[MeasurementKey]float64 {
+	values := make(map[MeasurementKey]float64, len(measurementCatalog))
+	for _, info := range measurementCatalog {
+		if v, ok := getMeasurement(front, side, volume, info.Key); ok {
+			values[info.Key] = v
+		}
+	}
+	return values
+}
+
+func getMeasurement(front *FrontParams, side *SideParams, volume *VolumeParams, key MeasurementKey) (float64, bool) {
+	info, ok := measurementCatalogByKey[key]
+	if !ok {
+		return 0, false
+	}
+	switch info.Group {
+	case MeasurementGroupFront:
+		if front != nil {
+			return front.get(key)
+		}
+	case MeasurementGroupSide:
+		if side != nil {
+			return side.get(key)
+		}
+	case MeasurementGroupVolume:
+		if volume != nil {
+			return volume.get(key)
+		}
+	}
+	return 0, false
+}
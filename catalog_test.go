@@ -0,0 +1,65 @@
+package saia
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMeasurementCatalog_InSync checks the generated catalog covers all the measurements of the params structs.
+// Run `make generate` when it fails.
+func TestMeasurementCatalog_InSync(t *testing.T) {
+	t.Parallel()
+
+	p := &Person{FrontParams: &FrontParams{}, SideParams: &SideParams{}, VolumeParams: &VolumeParams{}}
+	want := map[MeasurementKey]float64{}
+	value := 1.0
+	for group, params := range map[MeasurementGroup]any{
+		MeasurementGroupFront:  p.FrontParams,
+		MeasurementGroupSide:   p.SideParams,
+		MeasurementGroupVolume: p.VolumeParams,
+	} {
+		v := reflect.ValueOf(params).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.Type.Kind() != reflect.Float64 {
+				continue
+			}
+			jsonKey, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			v.Field(i).SetFloat(value)
+			want[MeasurementKey(string(group)+"."+jsonKey)] = value
+			value++
+		}
+	}
+
+	got := p.Measurements()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Measurements() = %v, want %v", got, want)
+	}
+	if len(MeasurementCatalog()) != len(want) {
+		t.Errorf("MeasurementCatalog() has %d measurements, want %d", len(MeasurementCatalog()), len(want))
+	}
+	for key, v := range want {
+		if info, ok := LookupMeasurement(key); !ok || string(info.Group)+"."+info.JSONKey != string(key) {
+			t.Errorf("LookupMeasurement(%q) = %v, %v", key, info, ok)
+		}
+		if got, ok := p.Get(key); !ok || got != v {
+			t.Errorf("Get(%q) = %v, %v, want %v", key, got, ok, v)
+		}
+	}
+}
+
+func TestPerson_Get(t *testing.T) {
+	t.Parallel()
+
+	p := &Person{VolumeParams: &VolumeParams{Waist: 80}}
+	if got, ok := p.Get(MeasurementKeyVolumeWaist); !ok || got != 80 {
+		t.Errorf("Get(volume.waist) = %v, %v, want 80, true", got, ok)
+	}
+	if _, ok := p.Get(MeasurementKeyFrontInseam); ok {
+		t.Errorf("Get(front.inseam) returns ok for not calculated front params")
+	}
+	if _, ok := p.Get("unknown"); ok {
+		t.Errorf("Get(unknown) returns ok")
+	}
+}
@@ -0,0 +1,227 @@
+package saia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitStateClosed means the requests are sent as usual
+	CircuitStateClosed CircuitState = iota
+	// CircuitStateOpen means the requests fail fast with ErrCircuitOpen
+	CircuitStateOpen
+	// CircuitStateHalfOpen means a few probe requests are sent to check whether the API is recovered
+	CircuitStateHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitStateClosed:
+		return "closed"
+	case CircuitStateOpen:
+		return "open"
+	case CircuitStateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerOptions is the configuration of the circuit breaker, zero values are replaced with the defaults.
+type CircuitBreakerOptions struct {
+	// FailureRatio is the ratio of the failed requests in the window to open the circuit, 0.5 by default
+	FailureRatio float64
+	// MinRequests is the minimum number of the requests in the window to open the circuit, 10 by default
+	MinRequests int
+	// Window is the interval to reset the counts of the requests, 1 minute by default
+	Window time.Duration
+	// OpenTimeout is the duration to keep the circuit open before probing, 30 seconds by default
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of the probe requests allowed in the half-open state, 1 by default
+	HalfOpenMaxRequests int
+	// OnStateChange is called when the state changes, e.g. to export it as a metric
+	OnStateChange func(from, to CircuitState)
+}
+
+func (o *CircuitBreakerOptions) withDefaults() *CircuitBreakerOptions {
+	opts := *o
+	if opts.FailureRatio <= 0 {
+		opts.FailureRatio = 0.5
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = 10
+	}
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = 30 * time.Second
+	}
+	if opts.HalfOpenMaxRequests <= 0 {
+		opts.HalfOpenMaxRequests = 1
+	}
+	return &opts
+}
+
+type circuitBreaker struct {
+	opts *CircuitBreakerOptions
+	now  func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	// openedAt is the time when the circuit is opened
+	openedAt time.Time
+	// probes is the number of the probe requests in flight in the half-open state
+	probes int
+	// transitions are the state changes to be notified after unlocking
+	transitions [][2]CircuitState
+}
+
+func newCircuitBreaker(opts *CircuitBreakerOptions) *circuitBreaker {
+	return &circuitBreaker{opts: opts.withDefaults(), now: time.Now}
+}
+
+// allow reports whether the request can be sent, and returns whether it's a probe request.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	now := b.now()
+	switch b.state {
+	case CircuitStateOpen:
+		if now.Sub(b.openedAt) < b.opts.OpenTimeout {
+			return false, ErrCircuitOpen
+		}
+		b.setState(CircuitStateHalfOpen)
+		fallthrough
+	case CircuitStateHalfOpen:
+		if b.probes >= b.opts.HalfOpenMaxRequests {
+			return false, ErrCircuitOpen
+		}
+		b.probes++
+		return true, nil
+	default:
+		if now.Sub(b.windowStart) >= b.opts.Window {
+			b.resetCounts(now)
+		}
+		return false, nil
+	}
+}
+
+// circuitResult is the result of a request for the circuit breaker.
+type circuitResult int
+
+const (
+	circuitSuccess circuitResult = iota
+	circuitFailure
+	// circuitIgnored is the request which tells nothing about the API, e.g. canceled by the caller
+	circuitIgnored
+)
+
+// record records the result of the request allowed by allow.
+func (b *circuitBreaker) record(probe bool, result circuitResult) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	if probe {
+		if b.state != CircuitStateHalfOpen {
+			return
+		}
+		b.probes--
+		switch result {
+		case circuitFailure:
+			b.open()
+		case circuitSuccess:
+			b.resetCounts(b.now())
+			b.setState(CircuitStateClosed)
+		}
+		// the ignored probe frees the slot for another probe and keeps the circuit half-open
+		return
+	}
+	if b.state != CircuitStateClosed || result == circuitIgnored {
+		return
+	}
+	b.requests++
+	if result == circuitFailure {
+		b.failures++
+	}
+	if b.requests >= b.opts.MinRequests && float64(b.failures)/float64(b.requests) >= b.opts.FailureRatio {
+		b.open()
+	}
+}
+
+func (b *circuitBreaker) open() {
+	b.openedAt = b.now()
+	b.probes = 0
+	b.setState(CircuitStateOpen)
+}
+
+func (b *circuitBreaker) resetCounts(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+func (b *circuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	b.transitions = append(b.transitions, [2]CircuitState{b.state, state})
+	b.state = state
+}
+
+// unlock unlocks the mutex and notifies the state changes, so that OnStateChange can call the client.
+func (b *circuitBreaker) unlock() {
+	transitions := b.transitions
+	b.transitions = nil
+	b.mu.Unlock()
+	if b.opts.OnStateChange == nil {
+		return
+	}
+	for _, t := range transitions {
+		b.opts.OnStateChange(t[0], t[1])
+	}
+}
+
+func (b *circuitBreaker) currentState() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// circuitResultOf reports whether the result of the request indicates the outage of the API.
+// The client errors (4xx) are not failures of the API,
+// and the cancellation by the caller is ignored since the API didn't respond either way.
+func circuitResultOf(req *http.Request, resp *http.Response, err error) circuitResult {
+	if err != nil {
+		if errors.Is(req.Context().Err(), context.Canceled) {
+			return circuitIgnored
+		}
+		return circuitFailure
+	}
+	if resp.StatusCode >= 500 {
+		return circuitFailure
+	}
+	return circuitSuccess
+}
+
+// CircuitState returns the current state of the circuit breaker, it's always closed when the circuit breaker is disabled.
+func (c *Client) CircuitState() CircuitState {
+	if c.apiClient.breaker == nil {
+		return CircuitStateClosed
+	}
+	return c.apiClient.breaker.currentState()
+}
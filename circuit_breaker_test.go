@@ -0,0 +1,149 @@
+package saia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		down atomic.Bool
+		hits atomic.Int32
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, `{"id": 1}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	var (
+		mu          sync.Mutex
+		transitions []string
+	)
+	now := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(&CircuitBreakerOptions{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		OpenTimeout:  10 * time.Second,
+		OnStateChange: func(from, to CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, fmt.Sprintf("%s->%s", from, to))
+		},
+	})
+	breaker.now = func() time.Time { return now }
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, breaker: breaker}}
+	ctx := context.Background()
+
+	// 2 of 4 requests fail, so the circuit is opened
+	for _, fail := range []bool{false, true, false, true} {
+		down.Store(fail)
+		_, _ = m.GetPerson(ctx, 1)
+	}
+	if got := breaker.currentState(); got != CircuitStateOpen {
+		t.Fatalf("state = %s, want open", got)
+	}
+
+	hitsBefore := hits.Load()
+	if _, err := m.GetPerson(ctx, 1); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetPerson() error = %v, want ErrCircuitOpen", err)
+	}
+	if hits.Load() != hitsBefore {
+		t.Errorf("GetPerson() called the API while the circuit is open")
+	}
+
+	// the probe fails, so the circuit is opened again
+	now = now.Add(10 * time.Second)
+	if _, err := m.GetPerson(ctx, 1); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetPerson() probe error = %v, want the API error", err)
+	}
+	if got := breaker.currentState(); got != CircuitStateOpen {
+		t.Fatalf("state = %s, want open", got)
+	}
+
+	// the probe succeeds, so the circuit is closed
+	down.Store(false)
+	now = now.Add(10 * time.Second)
+	if _, err := m.GetPerson(ctx, 1); err != nil {
+		t.Errorf("GetPerson() probe error = %v", err)
+	}
+	if got := breaker.currentState(); got != CircuitStateClosed {
+		t.Fatalf("state = %s, want closed", got)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if diff := cmp.Diff(transitions, want); diff != "" {
+		t.Errorf("transitions (-got, +want)\n%s", diff)
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	breaker := newCircuitBreaker(&CircuitBreakerOptions{MinRequests: 2})
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, breaker: breaker}}
+
+	for i := 0; i < 3; i++ {
+		_, _ = m.GetPerson(context.Background(), 1)
+	}
+	if got := breaker.currentState(); got != CircuitStateClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
+
+func TestCircuitBreaker_IgnoresCanceledProbe(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id": 1}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	now := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(&CircuitBreakerOptions{MinRequests: 1, OpenTimeout: 10 * time.Second})
+	breaker.now = func() time.Time { return now }
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, breaker: breaker}}
+	breaker.mu.Lock()
+	breaker.open()
+	breaker.unlock()
+	now = now.Add(10 * time.Second)
+
+	// the probe canceled by the caller tells nothing about the API
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.GetPerson(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetPerson() probe error = %v, want %v", err, context.Canceled)
+	}
+	if got := breaker.currentState(); got != CircuitStateHalfOpen {
+		t.Fatalf("state = %s, want half-open", got)
+	}
+
+	// the slot of the canceled probe is released for the next probe
+	if _, err := m.GetPerson(context.Background(), 1); err != nil {
+		t.Errorf("GetPerson() probe error = %v", err)
+	}
+	if got := breaker.currentState(); got != CircuitStateClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
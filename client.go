@@ -6,6 +6,7 @@ type Client struct {
 
 	PersonAPI      PersonAPI
 	MeasurementAPI MeasurementAPI
+	MtmClientAPI   MtmClientAPI
 }
 
 // NewClient creates a new SAIA client.
@@ -14,10 +15,11 @@ func NewClient(apiKey string, opt ...ClientOption) *Client {
 	for _, o := range append(opt, withAPIKey(apiKey)) {
 		o.apply(opts)
 	}
-	apiClient := newAPIClient(opts.HttpClient, opts.APIHost, opts.APIKey, opts.Debug)
+	apiClient := newAPIClient(opts)
 	return &Client{
 		apiClient:      apiClient,
 		PersonAPI:      newPersonAPI(apiClient),
 		MeasurementAPI: newMeasurementAPI(apiClient),
+		MtmClientAPI:   newMtmClientAPI(apiClient),
 	}
 }
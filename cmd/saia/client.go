@@ -0,0 +1,20 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/shing-dev/saia-go"
+)
+
+func newClient() (*saia.Client, error) {
+	apiKey := os.Getenv("SAIA_API_KEY")
+	if apiKey == "" {
+		return nil, errors.New("SAIA_API_KEY is not set")
+	}
+	var opts []saia.ClientOption
+	if apiHost := os.Getenv("SAIA_API_HOST"); apiHost != "" {
+		opts = append(opts, saia.WithAPIHost(apiHost))
+	}
+	return saia.NewClient(apiKey, opts...), nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shing-dev/saia-go"
+	"github.com/shing-dev/saia-go/ingest"
+)
+
+func runIngest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("ingest", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: saia ingest [options] <dir>\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	var (
+		manifest     = fs.String("manifest", "", "manifest CSV with id, gender, height, weight columns")
+		output       = fs.String("output", "", "output JSONL file (default stdout)")
+		statePath    = fs.String("state", "", "state file to resume a crashed run (default <output>.state when -output is set)")
+		concurrency  = fs.Int("concurrency", 4, "max number of subjects processed at the same time")
+		pollInterval = fs.Duration("poll-interval", 3*time.Second, "interval to poll the task set")
+		gender       = fs.String("gender", "", "default gender of subjects, male or female")
+		height       = fs.Int("height", 0, "default height of subjects in cm")
+		weight       = fs.Float64("weight", 0, "default weight of subjects in kg")
+		photoFlow    = fs.String("photo-flow", string(saia.PhotoFlowTypeFriend), "photo flow type, friend or hand")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	defaults := ingest.SubjectDefaults{
+		Gender:        saia.Gender(*gender),
+		Height:        *height,
+		Weight:        *weight,
+		PhotoFlowType: saia.PhotoFlowType(*photoFlow),
+	}
+	var (
+		subjects []*ingest.Subject
+		err      error
+	)
+	switch {
+	case *manifest != "":
+		subjects, err = ingest.LoadManifest(*manifest, defaults)
+	case fs.NArg() == 1:
+		subjects, err = ingest.ScanDir(fs.Arg(0), defaults)
+	default:
+		fs.Usage()
+		return errors.New("either -manifest or <dir> must be given")
+	}
+	if err != nil {
+		return fmt.Errorf("load subjects: %w", err)
+	}
+	for _, subject := range subjects {
+		if err := subject.Validate(); err != nil {
+			return fmt.Errorf("subject %q: %w", subject.ID, err)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("open output: %w", err)
+		}
+		defer f.Close()
+		w = f
+		if *statePath == "" {
+			*statePath = *output + ".state"
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	ingester := ingest.New(
+		client.PersonAPI,
+		ingest.WithConcurrency(*concurrency),
+		ingest.WithPollInterval(*pollInterval),
+		ingest.WithStatePath(*statePath),
+	)
+	summary, err := ingester.Run(ctx, subjects, w)
+	if summary != nil {
+		fmt.Fprintf(os.Stderr, "total: %d, succeeded: %d, failed: %d (retryable: %d), skipped: %d\n",
+			summary.Total, summary.Succeeded, summary.Failed, summary.Retryable, summary.Skipped)
+	}
+	return err
+}
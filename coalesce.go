@@ -0,0 +1,130 @@
+package saia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// CoalescingStats is the metrics of the request coalescing.
+type CoalescingStats struct {
+	// Requests is the number of the coalescable requests made by the callers
+	Requests int64
+	// Saved is the number of the requests which shared the response of an identical request in flight
+	// instead of calling the API
+	Saved int64
+}
+
+// flightGroup deduplicates the identical requests in flight like golang.org/x/sync/singleflight.
+type flightGroup struct {
+	mu       sync.Mutex
+	flights  map[string]*flight
+	requests atomic.Int64
+	saved    atomic.Int64
+}
+
+type flight struct {
+	done chan struct{}
+	resp *recordedResponse
+	err  error
+}
+
+// recordedResponse is the response read into memory to be shared by the callers.
+type recordedResponse struct {
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: map[string]*flight{}}
+}
+
+// do calls f once for the concurrent calls of the same key and returns its result to all of them.
+// shared is true when the result is made by another call.
+// The calls waiting for another call return the error of ctx when it's done first.
+func (g *flightGroup) do(ctx context.Context, key string, f func() (*recordedResponse, error)) (resp *recordedResponse, shared bool, err error) {
+	g.requests.Add(1)
+	g.mu.Lock()
+	if fl, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-fl.done:
+			return fl.resp, true, fl.err
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+	}
+	fl := &flight{done: make(chan struct{})}
+	g.flights[key] = fl
+	g.mu.Unlock()
+
+	fl.resp, fl.err = f()
+
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+	close(fl.done)
+	return fl.resp, false, fl.err
+}
+
+func (g *flightGroup) stats() CoalescingStats {
+	return CoalescingStats{Requests: g.requests.Load(), Saved: g.saved.Load()}
+}
+
+// doCoalesced sends the GET request sharing the response with the identical requests in flight.
+func (a *apiClient) doCoalesced(req *http.Request) (*http.Response, error) {
+	if a.flights == nil {
+		return a.do(req)
+	}
+
+	key := req.URL.String() + "\n" + req.Header.Get("If-None-Match") + "\n" + req.Header.Get("If-Modified-Since")
+	rec, shared, err := a.flights.do(req.Context(), key, func() (*recordedResponse, error) {
+		resp, err := a.do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &recordedResponse{status: resp.Status, statusCode: resp.StatusCode, header: resp.Header, body: body}, nil
+	})
+	if err != nil && shared {
+		// our context is done while waiting for the shared request
+		if req.Context().Err() != nil {
+			return nil, timeoutErr(req.Context(), req.Context().Err())
+		}
+		// the shared request is canceled by the context of another caller, so retry it with ours
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return a.do(req)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		a.flights.saved.Add(1)
+	}
+	return &http.Response{
+		Status:     rec.status,
+		StatusCode: rec.statusCode,
+		Header:     rec.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(rec.body)),
+		Request:    req,
+	}, nil
+}
+
+// CoalescingStats returns the metrics of the request coalescing of GetPerson, GetMeasurement and GetTaskSet.
+func (c *Client) CoalescingStats() CoalescingStats {
+	if c.apiClient.flights == nil {
+		return CoalescingStats{}
+	}
+	return c.apiClient.flights.stats()
+}
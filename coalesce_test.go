@@ -0,0 +1,149 @@
+package saia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_personAPI_GetTaskSet_Coalescing(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	release := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		fmt.Fprintln(w, `{"is_ready": false, "is_successful": false, "sub_tasks": []}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	flights := newFlightGroup()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, flights: flights}}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := m.GetTaskSet(context.Background(), "4d563d3f-38ae-4b51-8eab-2b78483b153e")
+			if err == nil && resp.TaskSet == nil {
+				err = fmt.Errorf("task set is nil")
+			}
+			errs[i] = err
+		}(i)
+	}
+	for flights.stats().Requests < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("GetTaskSet() [%d] error = %v", i, err)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("GetTaskSet() sent %d requests, want 1", hits)
+	}
+	if got, want := flights.stats(), (CoalescingStats{Requests: callers, Saved: callers - 1}); got != want {
+		t.Errorf("stats() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_personAPI_GetPerson_CoalescingRetriesCanceledLeader(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			// the leader is canceled while waiting for the response
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprintln(w, `{"id": 1}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	flights := newFlightGroup()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, flights: flights}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error)
+	go func() {
+		_, err := m.GetPerson(ctx, 1)
+		leaderDone <- err
+	}()
+	for atomic.LoadInt32(&hits) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	followerDone := make(chan error)
+	go func() {
+		_, err := m.GetPerson(context.Background(), 1)
+		followerDone <- err
+	}()
+	for flights.stats().Requests < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	if err := <-leaderDone; err == nil {
+		t.Errorf("GetPerson() of the canceled leader error = nil")
+	}
+	if err := <-followerDone; err != nil {
+		t.Errorf("GetPerson() of the follower error = %v", err)
+	}
+	// the follower sent its own request, so no request is saved
+	if got, want := flights.stats(), (CoalescingStats{Requests: 2, Saved: 0}); got != want {
+		t.Errorf("stats() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_personAPI_GetPerson_CoalescingFollowerDeadline(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	release := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		fmt.Fprintln(w, `{"id": 1}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	defer close(release)
+	flights := newFlightGroup()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, flights: flights}}
+
+	leaderDone := make(chan error, 1)
+	go func() {
+		_, err := m.GetPerson(context.Background(), 1)
+		leaderDone <- err
+	}()
+	for atomic.LoadInt32(&hits) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := m.GetPerson(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetPerson() of the follower error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetPerson() of the follower returned after %s", elapsed)
+	}
+	if got, want := flights.stats(), (CoalescingStats{Requests: 2, Saved: 0}); got != want {
+		t.Errorf("stats() = %+v, want %+v", got, want)
+	}
+}
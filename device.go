@@ -0,0 +1,217 @@
+package saia
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The angles of DeviceCoordinate follow the W3C DeviceOrientation Event specification:
+// the rotation from the earth frame (X east, Y north, Z up) to the device frame is
+// Z (AlphaZ, [0, 360)) then X' (BetaX, [-180, 180)) then Y'' (GammaY, [-90, 90)) in degrees.
+// A phone held upright in portrait facing the person has BetaX about 90 and GammaY about 0.
+
+// Validate returns an error when the angles are out of the half-open ranges of the DeviceOrientation Event.
+func (c *DeviceCoordinate) Validate() error {
+	var errs []error
+	for _, a := range []struct {
+		name     string
+		value    float64
+		min, max float64
+	}{
+		{name: "betaX", value: c.BetaX, min: -180, max: 180},
+		{name: "gammaY", value: c.GammaY, min: -90, max: 90},
+		{name: "alphaZ", value: c.AlphaZ, min: 0, max: 360},
+	} {
+		if math.IsNaN(a.value) || a.value < a.min || a.value >= a.max {
+			errs = append(errs, fmt.Errorf("%s %v is out of range [%v, %v)", a.name, a.value, a.min, a.max))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate returns an error when the angles of the photos are out of range.
+func (c *DeviceCoordinates) Validate() error {
+	var errs []error
+	if c.FrontPhoto != nil {
+		if err := c.FrontPhoto.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("front photo: %w", err))
+		}
+	}
+	if c.SidePhoto != nil {
+		if err := c.SidePhoto.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("side photo: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TiltBounds is the recommended orientation of the phone while taking the photos.
+type TiltBounds struct {
+	// MinBetaX and MaxBetaX are the bounds of the forward and backward tilt, 90 is upright
+	MinBetaX float64
+	MaxBetaX float64
+	// MaxGammaY is the bound of the absolute left and right tilt
+	MaxGammaY float64
+}
+
+// RecommendedTiltBounds returns the recommended orientation of the phone for the photo flow.
+// The hand flow has the tighter bounds since the phone stands by itself and the tilt distorts the whole body.
+func RecommendedTiltBounds(flow PhotoFlowType) TiltBounds {
+	if flow == PhotoFlowTypeHand {
+		return TiltBounds{MinBetaX: 80, MaxBetaX: 100, MaxGammaY: 5}
+	}
+	return TiltBounds{MinBetaX: 70, MaxBetaX: 110, MaxGammaY: 15}
+}
+
+// TiltWarning is a photo taken with the phone tilted outside of the recommended bounds.
+type TiltWarning struct {
+	Photo   PhotoSide
+	Message string
+}
+
+func (w *TiltWarning) String() string {
+	return fmt.Sprintf("%s photo: %s", w.Photo, w.Message)
+}
+
+// CheckTilt returns the warnings of the photos taken with the phone tilted outside of the recommended bounds of the flow.
+// The photos are uploaded regardless of the warnings, so check them before uploading to ask the user to retake.
+func (c *DeviceCoordinates) CheckTilt(flow PhotoFlowType) []*TiltWarning {
+	bounds := RecommendedTiltBounds(flow)
+	var warnings []*TiltWarning
+	for _, p := range []struct {
+		side       PhotoSide
+		coordinate *DeviceCoordinate
+	}{
+		{side: PhotoSideFront, coordinate: c.FrontPhoto},
+		{side: PhotoSideSide, coordinate: c.SidePhoto},
+	} {
+		if p.coordinate == nil {
+			continue
+		}
+		if beta := p.coordinate.BetaX; beta < bounds.MinBetaX || beta > bounds.MaxBetaX {
+			warnings = append(warnings, &TiltWarning{
+				Photo:   p.side,
+				Message: fmt.Sprintf("phone is tilted forward or backward by %.1f degrees, keep it within %.0f-%.0f", beta, bounds.MinBetaX, bounds.MaxBetaX),
+			})
+		}
+		if gamma := p.coordinate.GammaY; math.Abs(gamma) > bounds.MaxGammaY {
+			warnings = append(warnings, &TiltWarning{
+				Photo:   p.side,
+				Message: fmt.Sprintf("phone is tilted sideways by %.1f degrees, keep it within %.0f", gamma, bounds.MaxGammaY),
+			})
+		}
+	}
+	return warnings
+}
+
+// CheckTilt returns the warnings of the photos taken with the phone tilted outside of the recommended bounds.
+func (c *CreatePersonWithImagesParams) CheckTilt() []*TiltWarning {
+	if c.DeviceCoordinates == nil {
+		return nil
+	}
+	return c.DeviceCoordinates.CheckTilt(c.PhotoFlowType)
+}
+
+// CheckTilt returns the warnings of the photos taken with the phone tilted outside of the recommended bounds.
+func (p *PartialUpdatePersonParams) CheckTilt() []*TiltWarning {
+	if p.DeviceCoordinates == nil {
+		return nil
+	}
+	return p.DeviceCoordinates.CheckTilt(p.PhotoFlowType)
+}
+
+// DeviceCoordinateFromRotationMatrix converts the row-major rotation matrix from the device frame to the earth frame,
+// e.g. the output of SensorManager.getRotationMatrix on Android.
+func DeviceCoordinateFromRotationMatrix(m [9]float64) *DeviceCoordinate {
+	// the conversion of the DeviceOrientation Event specification which keeps gamma in [-90, 90)
+	var alpha, beta, gamma float64
+	switch {
+	case m[8] > 0:
+		alpha = math.Atan2(-m[1], m[4])
+		beta = math.Asin(m[7])
+		gamma = math.Atan2(-m[6], m[8])
+	case m[8] < 0:
+		alpha = math.Atan2(m[1], -m[4])
+		beta = -math.Asin(m[7])
+		if beta >= 0 {
+			beta -= math.Pi
+		} else {
+			beta += math.Pi
+		}
+		gamma = math.Atan2(m[6], -m[8])
+	case m[6] > 0:
+		alpha = math.Atan2(-m[1], m[4])
+		beta = math.Asin(m[7])
+		gamma = -math.Pi / 2
+	case m[6] < 0:
+		alpha = math.Atan2(m[1], -m[4])
+		beta = -math.Asin(m[7])
+		if beta >= 0 {
+			beta -= math.Pi
+		} else {
+			beta += math.Pi
+		}
+		gamma = -math.Pi / 2
+	default:
+		// gimbal lock, the rotation around Z and Y can't be distinguished
+		alpha = math.Atan2(m[3], m[0])
+		beta = math.Pi / 2
+		if m[7] < 0 {
+			beta = -math.Pi / 2
+		}
+	}
+	if alpha < 0 {
+		alpha += 2 * math.Pi
+	}
+	c := &DeviceCoordinate{BetaX: degrees(beta), GammaY: degrees(gamma), AlphaZ: degrees(alpha)}
+	// keep the angles in the half-open ranges against the rounding errors
+	if c.AlphaZ >= 360 {
+		c.AlphaZ -= 360
+	}
+	if c.BetaX >= 180 {
+		c.BetaX -= 360
+	}
+	return c
+}
+
+// DeviceCoordinateFromQuaternion converts the quaternion of the rotation from the device frame to the earth frame,
+// e.g. the rotation vector sensor on Android or the quaternion of CMAttitude on iOS.
+func DeviceCoordinateFromQuaternion(x, y, z, w float64) *DeviceCoordinate {
+	if n := math.Sqrt(x*x + y*y + z*z + w*w); n > 0 {
+		x, y, z, w = x/n, y/n, z/n, w/n
+	}
+	return DeviceCoordinateFromRotationMatrix([9]float64{
+		1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w),
+		2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w),
+		2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y),
+	})
+}
+
+// DeviceCoordinateFromIOSAttitude converts the Euler angles of CMAttitude on iOS in radians.
+func DeviceCoordinateFromIOSAttitude(roll, pitch, yaw float64) *DeviceCoordinate {
+	// CMAttitude applies yaw around Z, pitch around X and roll around Y in this order, which is the same as the W3C
+	return DeviceCoordinateFromRotationMatrix(rotationMatrixZXY(yaw, pitch, roll))
+}
+
+// DeviceCoordinateFromAndroidOrientation converts the output of SensorManager.getOrientation on Android in radians.
+func DeviceCoordinateFromAndroidOrientation(azimuth, pitch, roll float64) *DeviceCoordinate {
+	// getOrientation measures azimuth clockwise and pitch in the opposite direction of the W3C
+	return DeviceCoordinateFromRotationMatrix(rotationMatrixZXY(-azimuth, -pitch, roll))
+}
+
+// rotationMatrixZXY returns the row-major rotation matrix of Rz(alpha) * Rx(beta) * Ry(gamma).
+func rotationMatrixZXY(alpha, beta, gamma float64) [9]float64 {
+	sa, ca := math.Sincos(alpha)
+	sb, cb := math.Sincos(beta)
+	sg, cg := math.Sincos(gamma)
+	return [9]float64{
+		ca*cg - sa*sb*sg, -sa * cb, ca*sg + sa*sb*cg,
+		sa*cg + ca*sb*sg, ca * cb, sa*sg - ca*sb*cg,
+		-cb * sg, sb, cb * cg,
+	}
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
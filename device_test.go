@@ -0,0 +1,181 @@
+package saia
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDeviceCoordinates_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		coordinates *DeviceCoordinates
+		wantErr     bool
+	}{
+		{
+			name: "Valid",
+			coordinates: &DeviceCoordinates{
+				FrontPhoto: &DeviceCoordinate{BetaX: 90, GammaY: -2, AlphaZ: 180},
+				SidePhoto:  &DeviceCoordinate{BetaX: -180, GammaY: -90, AlphaZ: 0},
+			},
+		},
+		{
+			name:        "Exclusive upper bound of betaX",
+			coordinates: &DeviceCoordinates{FrontPhoto: &DeviceCoordinate{BetaX: 180}},
+			wantErr:     true,
+		},
+		{
+			name:        "Exclusive upper bound of gammaY",
+			coordinates: &DeviceCoordinates{FrontPhoto: &DeviceCoordinate{BetaX: 90, GammaY: 90}},
+			wantErr:     true,
+		},
+		{
+			name:        "Exclusive upper bound of alphaZ",
+			coordinates: &DeviceCoordinates{FrontPhoto: &DeviceCoordinate{BetaX: 90, AlphaZ: 360}},
+			wantErr:     true,
+		},
+		{
+			name:        "No photos",
+			coordinates: &DeviceCoordinates{},
+		},
+		{
+			name:        "Out of range",
+			coordinates: &DeviceCoordinates{FrontPhoto: &DeviceCoordinate{BetaX: 90, GammaY: 120, AlphaZ: 10}},
+			wantErr:     true,
+		},
+		{
+			name:        "Negative alpha",
+			coordinates: &DeviceCoordinates{SidePhoto: &DeviceCoordinate{BetaX: 90, AlphaZ: -1}},
+			wantErr:     true,
+		},
+		{
+			name:        "NaN",
+			coordinates: &DeviceCoordinates{SidePhoto: &DeviceCoordinate{BetaX: math.NaN()}},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.coordinates.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeviceCoordinates_CheckTilt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		coordinates *DeviceCoordinates
+		flow        PhotoFlowType
+		want        []PhotoSide
+	}{
+		{
+			name: "Upright",
+			coordinates: &DeviceCoordinates{
+				FrontPhoto: &DeviceCoordinate{BetaX: 90, GammaY: 1},
+				SidePhoto:  &DeviceCoordinate{BetaX: 92, GammaY: -3},
+			},
+			flow: PhotoFlowTypeHand,
+		},
+		{
+			name: "Within the friend flow bounds",
+			coordinates: &DeviceCoordinates{
+				FrontPhoto: &DeviceCoordinate{BetaX: 75, GammaY: 10},
+			},
+			flow: PhotoFlowTypeFriend,
+		},
+		{
+			name: "Outside of the hand flow bounds",
+			coordinates: &DeviceCoordinates{
+				FrontPhoto: &DeviceCoordinate{BetaX: 75, GammaY: 10},
+				SidePhoto:  &DeviceCoordinate{BetaX: 90, GammaY: 8},
+			},
+			flow: PhotoFlowTypeHand,
+			want: []PhotoSide{PhotoSideFront, PhotoSideFront, PhotoSideSide},
+		},
+		{
+			name: "Lying on the table",
+			coordinates: &DeviceCoordinates{
+				SidePhoto: &DeviceCoordinate{BetaX: 0},
+			},
+			flow: PhotoFlowTypeFriend,
+			want: []PhotoSide{PhotoSideSide},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []PhotoSide
+			for _, w := range tt.coordinates.CheckTilt(tt.flow) {
+				got = append(got, w.Photo)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("CheckTilt() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeviceCoordinateFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want *DeviceCoordinate
+	}{
+		{name: "Flat", want: &DeviceCoordinate{BetaX: 0, GammaY: 0, AlphaZ: 0}},
+		{name: "Upright", want: &DeviceCoordinate{BetaX: 90, GammaY: 0, AlphaZ: 0}},
+		{name: "Upright and tilted", want: &DeviceCoordinate{BetaX: 85, GammaY: -4, AlphaZ: 30}},
+		{name: "Upside down", want: &DeviceCoordinate{BetaX: -150, GammaY: 20, AlphaZ: 270}},
+		{name: "Leaning backward", want: &DeviceCoordinate{BetaX: 120, GammaY: 10, AlphaZ: 45}},
+	}
+	opt := cmpopts.EquateApprox(0, 1e-6)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alpha, beta, gamma := radians(tt.want.AlphaZ), radians(tt.want.BetaX), radians(tt.want.GammaY)
+			m := rotationMatrixZXY(alpha, beta, gamma)
+			if diff := cmp.Diff(DeviceCoordinateFromRotationMatrix(m), tt.want, opt); diff != "" {
+				t.Errorf("DeviceCoordinateFromRotationMatrix() mismatch (-got, +want):\n%s", diff)
+			}
+
+			// the quaternion of Rz(alpha) * Rx(beta) * Ry(gamma)
+			sa, ca := math.Sincos(alpha / 2)
+			sb, cb := math.Sincos(beta / 2)
+			sg, cg := math.Sincos(gamma / 2)
+			w := ca*cb*cg - sa*sb*sg
+			x := ca*sb*cg - sa*cb*sg
+			y := ca*cb*sg + sa*sb*cg
+			z := sa*cb*cg + ca*sb*sg
+			if diff := cmp.Diff(DeviceCoordinateFromQuaternion(2*x, 2*y, 2*z, 2*w), tt.want, opt); diff != "" {
+				t.Errorf("DeviceCoordinateFromQuaternion() mismatch (-got, +want):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(DeviceCoordinateFromIOSAttitude(gamma, beta, alpha), tt.want, opt); diff != "" {
+				t.Errorf("DeviceCoordinateFromIOSAttitude() mismatch (-got, +want):\n%s", diff)
+			}
+			if diff := cmp.Diff(DeviceCoordinateFromAndroidOrientation(-alpha, -beta, gamma), tt.want, opt); diff != "" {
+				t.Errorf("DeviceCoordinateFromAndroidOrientation() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,173 @@
+package saia
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MeasurementDelta is the change of a measurement between two calculations.
+type MeasurementDelta struct {
+	// Key is the group and JSON key of the measurement, e.g. "volume.waist"
+	Key    MeasurementKey
+	Before float64
+	After  float64
+	// Absolute is After - Before
+	Absolute float64
+	// Relative is Absolute / Before, it's 0 when Before is 0
+	Relative float64
+	// Significant is true when the change exceeds the thresholds
+	Significant bool
+}
+
+// MeasurementDiff is the changes of measurements between two calculations.
+type MeasurementDiff struct {
+	Deltas []*MeasurementDelta
+}
+
+type DiffOptions struct {
+	// AbsoluteThreshold is the minimum absolute change in cm of the lengths to be significant
+	AbsoluteThreshold float64
+	// AngleThreshold is the minimum absolute change in degrees of the angles to be significant, e.g. front.shoulder_slope
+	AngleThreshold float64
+	// RelativeThreshold is the minimum relative change of the lengths to be significant, e.g. 0.05 for 5%
+	RelativeThreshold float64
+}
+
+func newDefaultDiffOptions() *DiffOptions {
+	return &DiffOptions{
+		AbsoluteThreshold: 1,
+		AngleThreshold:    1,
+		RelativeThreshold: 0.03,
+	}
+}
+
+type DiffOption func(*DiffOptions)
+
+func DiffOptionAbsoluteThreshold(cm float64) DiffOption {
+	return func(o *DiffOptions) {
+		o.AbsoluteThreshold = cm
+	}
+}
+
+func DiffOptionAngleThreshold(degrees float64) DiffOption {
+	return func(o *DiffOptions) {
+		o.AngleThreshold = degrees
+	}
+}
+
+func DiffOptionRelativeThreshold(ratio float64) DiffOption {
+	return func(o *DiffOptions) {
+		o.RelativeThreshold = ratio
+	}
+}
+
+// Diff returns the changes of measurements from person a to person b, e.g. between the scan and the re-scan.
+// A change is significant when it exceeds both of the absolute and relative thresholds,
+// or the angle threshold for the angles.
+func Diff(a, b *Person, opts ...DiffOption) *MeasurementDiff {
+	return diffParams(
+		paramValues(a.FrontParams, a.SideParams, a.VolumeParams),
+		paramValues(b.FrontParams, b.SideParams, b.VolumeParams),
+		opts...,
+	)
+}
+
+// DiffTaskSets returns the changes of measurements from calculation a to calculation b of the person.
+func DiffTaskSets(a, b *PersonTaskSet, opts ...DiffOption) *MeasurementDiff {
+	return diffParams(
+		paramValues(a.FrontParams, a.SideParams, a.VolumeParams),
+		paramValues(b.FrontParams, b.SideParams, b.VolumeParams),
+		opts...,
+	)
+}
+
+func diffParams(before, after []*paramValue, opt ...DiffOption) *MeasurementDiff {
+	opts := newDefaultDiffOptions()
+	for _, o := range opt {
+		o(opts)
+	}
+
+	afterByKey := make(map[MeasurementKey]float64, len(after))
+	for _, v := range after {
+		afterByKey[v.key] = v.value
+	}
+
+	diff := &MeasurementDiff{}
+	for _, b := range before {
+		a, ok := afterByKey[b.key]
+		// measurements not calculated in either of them can't be compared
+		if !ok || (a == 0 && b.value == 0) {
+			continue
+		}
+		delta := &MeasurementDelta{
+			Key:      b.key,
+			Before:   b.value,
+			After:    a,
+			Absolute: a - b.value,
+		}
+		if b.value != 0 {
+			delta.Relative = delta.Absolute / b.value
+		}
+		if info, ok := measurementCatalogByKey[b.key]; ok && info.Kind == MeasurementKindAngle {
+			// the relative change of an angle is meaningless, e.g. around 0 degrees
+			delta.Significant = math.Abs(delta.Absolute) >= opts.AngleThreshold
+		} else {
+			delta.Significant = math.Abs(delta.Absolute) >= opts.AbsoluteThreshold &&
+				(b.value == 0 || math.Abs(delta.Relative) >= opts.RelativeThreshold)
+		}
+		diff.Deltas = append(diff.Deltas, delta)
+	}
+	return diff
+}
+
+// Significant returns the significant changes.
+func (d *MeasurementDiff) Significant() []*MeasurementDelta {
+	var deltas []*MeasurementDelta
+	for _, delta := range d.Deltas {
+		if delta.Significant {
+			deltas = append(deltas, delta)
+		}
+	}
+	return deltas
+}
+
+// HasSignificantChanges reports whether any measurement changed significantly.
+func (d *MeasurementDiff) HasSignificantChanges() bool {
+	return len(d.Significant()) > 0
+}
+
+// Report returns a human-readable report of the significant changes.
+func (d *MeasurementDiff) Report() string {
+	significant := d.Significant()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d measurements changed significantly\n", len(significant), len(d.Deltas))
+	for _, delta := range significant {
+		relative := "n/a"
+		if delta.Before != 0 {
+			relative = fmt.Sprintf("%+.1f%%", delta.Relative*100)
+		}
+		fmt.Fprintf(&sb, "  %s: %.1f -> %.1f (%+.1f, %s)\n", delta.Key, delta.Before, delta.After, delta.Absolute, relative)
+	}
+	return sb.String()
+}
+
+type paramValue struct {
+	key   MeasurementKey
+	value float64
+}
+
+// paramValues returns the calculated measurements of the params keyed by the group and JSON key in the catalog order.
+// The percentages are skipped since they are not measured but derived from the other measurements.
+func paramValues(front *FrontParams, side *SideParams, volume *VolumeParams) []*paramValue {
+	var values []*paramValue
+	for _, info := range measurementCatalog {
+		if info.Kind == MeasurementKindPercentage {
+			continue
+		}
+		if v, ok := getMeasurement(front, side, volume, info.Key); ok {
+			values = append(values, &paramValue{key: info.Key, value: v})
+		}
+	}
+	return values
+}
@@ -0,0 +1,78 @@
+package saia
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	before := &Person{
+		FrontParams:  &FrontParams{Inseam: 80, ShoulderSlope: 20, Neck: 35},
+		VolumeParams: &VolumeParams{Waist: 80, Chest: 100},
+	}
+	after := &Person{
+		FrontParams:  &FrontParams{Inseam: 80.5, ShoulderSlope: 20.5, Neck: 35},
+		SideParams:   &SideParams{NeckToChest: 20},
+		VolumeParams: &VolumeParams{Waist: 90, Chest: 101.5},
+	}
+
+	tests := []struct {
+		name       string
+		opts       []DiffOption
+		wantDeltas []*MeasurementDelta
+		wantReport string
+	}{
+		{
+			name: "Default thresholds",
+			wantDeltas: []*MeasurementDelta{
+				{Key: MeasurementKeyFrontInseam, Before: 80, After: 80.5, Absolute: 0.5, Relative: 0.00625},
+				{Key: MeasurementKeyFrontShoulderSlope, Before: 20, After: 20.5, Absolute: 0.5, Relative: 0.025},
+				{Key: MeasurementKeyFrontNeck, Before: 35, After: 35},
+				{Key: MeasurementKeyVolumeChest, Before: 100, After: 101.5, Absolute: 1.5, Relative: 0.015},
+				{Key: MeasurementKeyVolumeWaist, Before: 80, After: 90, Absolute: 10, Relative: 0.125, Significant: true},
+			},
+			wantReport: "1 of 5 measurements changed significantly\n  volume.waist: 80.0 -> 90.0 (+10.0, +12.5%)\n",
+		},
+		{
+			name: "Custom thresholds",
+			opts: []DiffOption{DiffOptionAbsoluteThreshold(0.5), DiffOptionRelativeThreshold(0.01)},
+			wantDeltas: []*MeasurementDelta{
+				{Key: MeasurementKeyFrontInseam, Before: 80, After: 80.5, Absolute: 0.5, Relative: 0.00625},
+				{Key: MeasurementKeyFrontShoulderSlope, Before: 20, After: 20.5, Absolute: 0.5, Relative: 0.025},
+				{Key: MeasurementKeyFrontNeck, Before: 35, After: 35},
+				{Key: MeasurementKeyVolumeChest, Before: 100, After: 101.5, Absolute: 1.5, Relative: 0.015, Significant: true},
+				{Key: MeasurementKeyVolumeWaist, Before: 80, After: 90, Absolute: 10, Relative: 0.125, Significant: true},
+			},
+			wantReport: "2 of 5 measurements changed significantly\n  volume.chest: 100.0 -> 101.5 (+1.5, +1.5%)\n  volume.waist: 80.0 -> 90.0 (+10.0, +12.5%)\n",
+		},
+		{
+			name: "Angle threshold",
+			opts: []DiffOption{DiffOptionAngleThreshold(0.5)},
+			wantDeltas: []*MeasurementDelta{
+				{Key: MeasurementKeyFrontInseam, Before: 80, After: 80.5, Absolute: 0.5, Relative: 0.00625},
+				{Key: MeasurementKeyFrontShoulderSlope, Before: 20, After: 20.5, Absolute: 0.5, Relative: 0.025, Significant: true},
+				{Key: MeasurementKeyFrontNeck, Before: 35, After: 35},
+				{Key: MeasurementKeyVolumeChest, Before: 100, After: 101.5, Absolute: 1.5, Relative: 0.015},
+				{Key: MeasurementKeyVolumeWaist, Before: 80, After: 90, Absolute: 10, Relative: 0.125, Significant: true},
+			},
+			wantReport: "2 of 5 measurements changed significantly\n  front.shoulder_slope: 20.0 -> 20.5 (+0.5, +2.5%)\n  volume.waist: 80.0 -> 90.0 (+10.0, +12.5%)\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Diff(before, after, tt.opts...)
+			if diff := cmp.Diff(got.Deltas, tt.wantDeltas); diff != "" {
+				t.Errorf("Diff() (-got, +want)\n%s", diff)
+			}
+			if diff := cmp.Diff(got.Report(), tt.wantReport); diff != "" {
+				t.Errorf("Report() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
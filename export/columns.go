@@ -0,0 +1,93 @@
+package export
+
+import (
+	"github.com/shing-dev/saia-go"
+)
+
+// column is a flattened field of a record.
+type column[T any] struct {
+	key string
+	// isLength is true when the value is a length in cm which can be converted to the other units
+	isLength bool
+	value    func(T) any
+}
+
+// measurementParamColumns returns the columns of all the measurements in the catalog order.
+func measurementParamColumns[T any](get func(T, saia.MeasurementKey) (float64, bool)) []*column[T] {
+	var columns []*column[T]
+	for _, info := range saia.MeasurementCatalog() {
+		key := info.Key
+		columns = append(columns, &column[T]{
+			key:      string(key),
+			isLength: info.Kind.IsLength(),
+			value: func(record T) any {
+				v, ok := get(record, key)
+				if !ok {
+					return nil
+				}
+				return v
+			},
+		})
+	}
+	return columns
+}
+
+var personColumns = append([]*column[*saia.Person]{
+	{key: "id", value: func(p *saia.Person) any { return p.ID }},
+	{key: "gender", value: func(p *saia.Person) any { return p.Gender }},
+	{key: "height", isLength: true, value: func(p *saia.Person) any { return float64(p.Height) }},
+	{key: "weight", value: func(p *saia.Person) any { return p.Weight }},
+	{key: "created", value: func(p *saia.Person) any { return p.Created }},
+	{key: "photo_flow", value: func(p *saia.Person) any { return p.PhotoFlow }},
+	{key: "country_code", value: func(p *saia.Person) any { return p.CountryCode }},
+	{key: "is_viewed", value: func(p *saia.Person) any { return p.IsViewed }},
+	{key: "is_archived", value: func(p *saia.Person) any { return p.IsArchived }},
+}, measurementParamColumns((*saia.Person).Get)...)
+
+// measurementValue returns the measurement of the primary calculation of the measured person.
+func measurementValue(m *saia.Measurement, key saia.MeasurementKey) (float64, bool) {
+	ts := m.PrimaryTaskSet()
+	if ts == nil {
+		return 0, false
+	}
+	return ts.Get(key)
+}
+
+var measurementColumns = append([]*column[*saia.Measurement]{
+	{key: "id", value: func(m *saia.Measurement) any { return m.ID }},
+	{key: "uuid", value: func(m *saia.Measurement) any { return m.UUID }},
+	{key: "status", value: func(m *saia.Measurement) any { return m.Status }},
+	{key: "created", value: func(m *saia.Measurement) any { return m.Created }},
+	{key: "updated", value: func(m *saia.Measurement) any { return m.Updated }},
+	{key: "email", value: func(m *saia.Measurement) any { return m.Email }},
+	{key: "unit", value: func(m *saia.Measurement) any { return m.Unit }},
+	{key: "source", value: func(m *saia.Measurement) any { return m.Source }},
+	{key: "notes", value: func(m *saia.Measurement) any { return m.Notes }},
+	{key: "is_viewed", value: func(m *saia.Measurement) any { return m.IsViewed }},
+	{key: "is_archived", value: func(m *saia.Measurement) any { return m.IsArchived }},
+	{key: "mtm_client.first_name", value: func(m *saia.Measurement) any { return m.MtmClient.FirstName }},
+	{key: "mtm_client.last_name", value: func(m *saia.Measurement) any { return m.MtmClient.LastName }},
+	{key: "mtm_client.email", value: func(m *saia.Measurement) any { return m.MtmClient.Email }},
+	{key: "person.id", value: func(m *saia.Measurement) any { return m.Person.ID }},
+	{key: "person.gender", value: func(m *saia.Measurement) any { return m.Person.Gender }},
+	{key: "person.height", isLength: true, value: func(m *saia.Measurement) any { return float64(m.Person.Height) }},
+	{key: "person.weight", value: func(m *saia.Measurement) any { return m.Person.Weight }},
+}, measurementParamColumns(measurementValue)...)
+
+func columnKeys[T any](columns []*column[T]) []string {
+	keys := make([]string, 0, len(columns))
+	for _, c := range columns {
+		keys = append(keys, c.key)
+	}
+	return keys
+}
+
+// PersonColumns returns all the column keys of person export in the default order.
+func PersonColumns() []string {
+	return columnKeys(personColumns)
+}
+
+// MeasurementColumns returns all the column keys of measurement export in the default order.
+func MeasurementColumns() []string {
+	return columnKeys(measurementColumns)
+}
@@ -0,0 +1,291 @@
+// Package export writes persons and measurements as flat records in CSV, TSV and JSONL.
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/shing-dev/saia-go"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
+	FormatJSONL Format = "jsonl"
+)
+
+// Unit is the unit of length columns.
+type Unit string
+
+const (
+	UnitCentimeter Unit = "cm"
+	UnitMillimeter Unit = "mm"
+	UnitInch       Unit = "in"
+)
+
+func (u Unit) validate() error {
+	switch u {
+	case UnitCentimeter, UnitMillimeter, UnitInch:
+		return nil
+	default:
+		return fmt.Errorf("unsupported unit %q", u)
+	}
+}
+
+// fromCentimeter converts the length in cm to the unit, the unit must be validated.
+func (u Unit) fromCentimeter(v float64) float64 {
+	switch u {
+	case UnitMillimeter:
+		return v * 10
+	case UnitInch:
+		return v / 2.54
+	default:
+		return v
+	}
+}
+
+type Options struct {
+	// Columns are the keys of the columns to be written in the order
+	// all the columns are written when it's empty
+	Columns []string
+	Unit    Unit
+	// ExcelCompatible writes UTF-8 BOM and CRLF line endings for CSV and TSV,
+	// so that they are opened correctly by spreadsheet applications
+	ExcelCompatible bool
+	// NoHeader omits the header line of CSV and TSV
+	NoHeader bool
+}
+
+func newDefaultOptions() *Options {
+	return &Options{
+		Unit: UnitCentimeter,
+	}
+}
+
+// Option is a option to change export configuration.
+type Option func(*Options)
+
+// WithColumns selects and orders the columns by their keys.
+func WithColumns(keys ...string) Option {
+	return func(o *Options) {
+		o.Columns = keys
+	}
+}
+
+func WithUnit(unit Unit) Option {
+	return func(o *Options) {
+		o.Unit = unit
+	}
+}
+
+func WithExcelCompatible() Option {
+	return func(o *Options) {
+		o.ExcelCompatible = true
+	}
+}
+
+func WithNoHeader() Option {
+	return func(o *Options) {
+		o.NoHeader = true
+	}
+}
+
+// Persons writes the persons returned by the iterator and returns the number of written records.
+func Persons(w io.Writer, format Format, it Iterator[*saia.Person], opt ...Option) (int, error) {
+	return export(w, format, personColumns, it, opt...)
+}
+
+// Measurements writes the measurements returned by the iterator and returns the number of written records.
+// The measurement params are taken from the primary task set of the measured person.
+func Measurements(w io.Writer, format Format, it Iterator[*saia.Measurement], opt ...Option) (int, error) {
+	return export(w, format, measurementColumns, it, opt...)
+}
+
+func export[T any](w io.Writer, format Format, allColumns []*column[T], it Iterator[T], opt ...Option) (int, error) {
+	opts := newDefaultOptions()
+	for _, o := range opt {
+		o(opts)
+	}
+	if err := opts.Unit.validate(); err != nil {
+		return 0, err
+	}
+	columns, err := selectColumns(allColumns, opts.Columns)
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	rw, err := newRecordWriter(bw, format, opts)
+	if err != nil {
+		return 0, err
+	}
+	keys := columnKeys(columns)
+	if !opts.NoHeader {
+		if err := rw.writeHeader(keys); err != nil {
+			return 0, fmt.Errorf("write header: %w", err)
+		}
+	}
+
+	n := 0
+	values := make([]any, len(columns))
+	for {
+		record, err := it()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("iterate records: %w", err)
+		}
+		for i, c := range columns {
+			values[i] = c.value(record)
+			if v, ok := values[i].(float64); ok && c.isLength {
+				values[i] = opts.Unit.fromCentimeter(v)
+			}
+		}
+		if err := rw.writeRecord(keys, values); err != nil {
+			return n, fmt.Errorf("write record: %w", err)
+		}
+		n++
+	}
+	if err := rw.flush(); err != nil {
+		return n, err
+	}
+	return n, bw.Flush()
+}
+
+func selectColumns[T any](allColumns []*column[T], keys []string) ([]*column[T], error) {
+	if len(keys) == 0 {
+		return allColumns, nil
+	}
+	columnsByKey := make(map[string]*column[T], len(allColumns))
+	for _, c := range allColumns {
+		columnsByKey[c.key] = c
+	}
+	columns := make([]*column[T], 0, len(keys))
+	for _, key := range keys {
+		c, ok := columnsByKey[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", key)
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+type recordWriter interface {
+	writeHeader(keys []string) error
+	writeRecord(keys []string, values []any) error
+	flush() error
+}
+
+func newRecordWriter(w io.Writer, format Format, opts *Options) (recordWriter, error) {
+	switch format {
+	case FormatCSV, FormatTSV:
+		if opts.ExcelCompatible {
+			if _, err := io.WriteString(w, "\ufeff"); err != nil {
+				return nil, err
+			}
+		}
+		cw := csv.NewWriter(w)
+		cw.UseCRLF = opts.ExcelCompatible
+		if format == FormatTSV {
+			cw.Comma = '\t'
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatJSONL:
+		return &jsonlWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (c *csvWriter) writeHeader(keys []string) error {
+	return c.w.Write(keys)
+}
+
+func (c *csvWriter) writeRecord(_ []string, values []any) error {
+	c.record = c.record[:0]
+	for _, v := range values {
+		c.record = append(c.record, formatValue(v))
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonlWriter writes a record as a JSON object per line keeping the column order.
+type jsonlWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (j *jsonlWriter) writeHeader([]string) error {
+	return nil
+}
+
+func (j *jsonlWriter) writeRecord(keys []string, values []any) error {
+	j.buf.Reset()
+	j.buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			j.buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		j.buf.Write(k)
+		j.buf.WriteByte(':')
+		j.buf.Write(v)
+	}
+	j.buf.WriteString("}\n")
+	_, err := j.w.Write(j.buf.Bytes())
+	return err
+}
+
+func (j *jsonlWriter) flush() error {
+	return nil
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shing-dev/saia-go"
+	"github.com/shing-dev/saia-go/pkg/convutil"
+)
+
+func TestPersons(t *testing.T) {
+	t.Parallel()
+
+	persons := []*saia.Person{
+		{
+			ID:          1,
+			Gender:      saia.GenderFemale,
+			Height:      254,
+			Weight:      60.5,
+			Created:     time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+			FrontParams: &saia.FrontParams{Inseam: 25.4, ShoulderSlope: 20},
+			VolumeParams: &saia.VolumeParams{
+				Chest: 88.9,
+			},
+		},
+		{ID: 2, Gender: saia.GenderMale, Height: 170, Weight: 70},
+	}
+
+	tests := []struct {
+		name    string
+		format  Format
+		opts    []Option
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "CSV in inch",
+			format: FormatCSV,
+			opts:   []Option{WithColumns("id", "height", "front.inseam", "front.shoulder_slope", "volume.chest"), WithUnit(UnitInch)},
+			want:   "id,height,front.inseam,front.shoulder_slope,volume.chest\n1,100,10,20,35\n2,66.92913385826772,,,\n",
+		},
+		{
+			name:   "Excel compatible TSV without header",
+			format: FormatTSV,
+			opts:   []Option{WithColumns("gender", "created"), WithExcelCompatible(), WithNoHeader()},
+			want:   "\ufefffemale\t2023-04-01T00:00:00Z\r\nmale\t\r\n",
+		},
+		{
+			name:   "JSONL keeps the column order",
+			format: FormatJSONL,
+			opts:   []Option{WithColumns("weight", "id", "side.neck_to_chest"), WithUnit(UnitMillimeter)},
+			want:   "{\"weight\":60.5,\"id\":1,\"side.neck_to_chest\":null}\n{\"weight\":70,\"id\":2,\"side.neck_to_chest\":null}\n",
+		},
+		{
+			name:    "Unknown column",
+			format:  FormatCSV,
+			opts:    []Option{WithColumns("unknown")},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown unit",
+			format:  FormatCSV,
+			opts:    []Option{WithUnit("inch")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			_, err := Persons(&buf, tt.format, SliceIterator(persons), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Persons() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(buf.String(), tt.want); !tt.wantErr && diff != "" {
+				t.Errorf("Persons() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeMeasurementAPI struct {
+	saia.MeasurementAPI
+	pages [][]*saia.Measurement
+}
+
+func (f *fakeMeasurementAPI) GetMeasurementList(ctx context.Context, options ...saia.GetMeasurementListOption) (*saia.GetMeasurementListResponse, error) {
+	params := &saia.GetMeasurementListParams{}
+	for _, opt := range options {
+		opt(params)
+	}
+	resp := &saia.GetMeasurementListResponse{Results: f.pages[params.Page-1]}
+	if params.Page < len(f.pages) {
+		resp.Next = convutil.ToPointer("next")
+	}
+	return resp, nil
+}
+
+func TestMeasurements(t *testing.T) {
+	t.Parallel()
+
+	var m1 saia.Measurement
+	if err := json.Unmarshal([]byte(`{"id":1,"person":{"task_sets":[
+		{"is_successful":true,"is_primary":false,"volume_params":{"waist":80}},
+		{"is_successful":true,"is_primary":true,"volume_params":{"waist":70}}
+	]}}`), &m1); err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeMeasurementAPI{pages: [][]*saia.Measurement{{&m1}, {{ID: 2}}}}
+
+	var buf bytes.Buffer
+	n, err := Measurements(&buf, FormatCSV, MeasurementListIterator(context.Background(), api), WithColumns("id", "volume.waist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("Measurements() n = %d, want 2", n)
+	}
+	if diff := cmp.Diff(buf.String(), "id,volume.waist\n1,70\n2,\n"); diff != "" {
+		t.Errorf("Measurements() (-got, +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/shing-dev/saia-go"
+)
+
+// Iterator returns the next record on each call, and io.EOF when there are no more records.
+type Iterator[T any] func() (T, error)
+
+// SliceIterator returns an iterator over the records in the slice.
+func SliceIterator[T any](records []T) Iterator[T] {
+	i := 0
+	return func() (T, error) {
+		if i >= len(records) {
+			var zero T
+			return zero, io.EOF
+		}
+		i++
+		return records[i-1], nil
+	}
+}
+
+// MeasurementListIterator returns an iterator which fetches the measurement list page by page.
+// Only the current page is kept in memory, so the whole measurement list can be exported.
+func MeasurementListIterator(ctx context.Context, measurementAPI saia.MeasurementAPI, options ...saia.GetMeasurementListOption) Iterator[*saia.Measurement] {
+	var (
+		page    = 1
+		results []*saia.Measurement
+		hasNext = true
+	)
+	return func() (*saia.Measurement, error) {
+		for len(results) == 0 {
+			if !hasNext {
+				return nil, io.EOF
+			}
+			opts := append(append([]saia.GetMeasurementListOption{}, options...), saia.GetMeasurementListOptionLimit(page))
+			resp, err := measurementAPI.GetMeasurementList(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			results = resp.Results
+			hasNext = resp.Next != nil && len(resp.Results) > 0
+			page++
+		}
+		m := results[0]
+		results = results[1:]
+		return m, nil
+	}
+}
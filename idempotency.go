@@ -0,0 +1,269 @@
+package saia
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header to send the idempotency key to the server.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyStatus is the state of the operation recorded with the idempotency key.
+type IdempotencyStatus string
+
+const (
+	// IdempotencyStatusPending is the operation reserved the key and in flight
+	IdempotencyStatusPending IdempotencyStatus = "pending"
+	// IdempotencyStatusCompleted is the operation succeeded with the recorded response
+	IdempotencyStatusCompleted IdempotencyStatus = "completed"
+	// IdempotencyStatusUnknown is the operation which failed after sending the request, e.g. timed out,
+	// so it may or may not be processed by the server. It's resent with the same key for the server to deduplicate it.
+	IdempotencyStatusUnknown IdempotencyStatus = "unknown"
+)
+
+// ErrIdempotencyKeyPending is returned when the operation with the same idempotency key is in flight.
+var ErrIdempotencyKeyPending = errors.New("operation with the idempotency key is in flight")
+
+// IdempotencyRecord is the result of the operation recorded with the idempotency key.
+type IdempotencyRecord struct {
+	Operation Operation         `json:"operation"`
+	Status    IdempotencyStatus `json:"status"`
+	// Response is the JSON of the response of the operation, it's set when the operation is completed
+	Response json.RawMessage `json:"response,omitempty"`
+	// Created is the time when the key is reserved
+	Created time.Time `json:"created"`
+}
+
+// IdempotencyStore records the results of the operations with the idempotency keys.
+// Use a persistent store shared by the replicas to survive restarts, the client uses an in-memory store by default.
+type IdempotencyStore interface {
+	// Reserve records the pending record of the key unless the key is already recorded.
+	// It must be atomic, reserved is false with the existing record when the key is already recorded.
+	Reserve(ctx context.Context, key string, record *IdempotencyRecord) (existing *IdempotencyRecord, reserved bool, err error)
+	// Put replaces the record of the reserved key, e.g. with the completed response
+	Put(ctx context.Context, key string, record *IdempotencyRecord) error
+	// Delete releases the key of the operation which is known not to be processed, so that it can be retried
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyMaxSize = 10000
+)
+
+// MemoryIdempotencyStore is an IdempotencyStore which keeps the records in memory.
+// The records expire after the TTL, and the oldest records are evicted when the store exceeds the max size.
+type MemoryIdempotencyStore struct {
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+
+	mu      sync.Mutex
+	records map[string]*list.Element
+	// order is the keys in the order of the reservation, the front is the oldest
+	order *list.List
+}
+
+type memoryIdempotencyEntry struct {
+	key    string
+	record *IdempotencyRecord
+}
+
+type MemoryIdempotencyStoreOption func(*MemoryIdempotencyStore)
+
+// MemoryIdempotencyStoreOptionTTL sets the duration to keep the records, 24 hours by default.
+func MemoryIdempotencyStoreOptionTTL(ttl time.Duration) MemoryIdempotencyStoreOption {
+	return func(s *MemoryIdempotencyStore) {
+		s.ttl = ttl
+	}
+}
+
+// MemoryIdempotencyStoreOptionMaxSize sets the max number of the records, 10000 by default.
+func MemoryIdempotencyStoreOptionMaxSize(size int) MemoryIdempotencyStoreOption {
+	return func(s *MemoryIdempotencyStore) {
+		s.maxSize = size
+	}
+}
+
+func NewMemoryIdempotencyStore(options ...MemoryIdempotencyStoreOption) *MemoryIdempotencyStore {
+	s := &MemoryIdempotencyStore{
+		ttl:     defaultIdempotencyTTL,
+		maxSize: defaultIdempotencyMaxSize,
+		now:     time.Now,
+		records: map[string]*list.Element{},
+		order:   list.New(),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, key string, record *IdempotencyRecord) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict()
+	if e, ok := s.records[key]; ok {
+		return e.Value.(*memoryIdempotencyEntry).record, false, nil
+	}
+	s.records[key] = s.order.PushBack(&memoryIdempotencyEntry{key: key, record: record})
+	for s.maxSize > 0 && s.order.Len() > s.maxSize {
+		s.remove(s.order.Front())
+	}
+	return nil, true, nil
+}
+
+func (s *MemoryIdempotencyStore) Put(ctx context.Context, key string, record *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.records[key]; ok {
+		e.Value.(*memoryIdempotencyEntry).record = record
+		return nil
+	}
+	// the reservation is evicted while the operation is in flight
+	s.records[key] = s.order.PushBack(&memoryIdempotencyEntry{key: key, record: record})
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.records[key]; ok {
+		s.remove(e)
+	}
+	return nil
+}
+
+// Len returns the number of the records in the store.
+func (s *MemoryIdempotencyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// evict removes the expired records from the oldest.
+func (s *MemoryIdempotencyStore) evict() {
+	if s.ttl <= 0 {
+		return
+	}
+	now := s.now()
+	for e := s.order.Front(); e != nil; e = s.order.Front() {
+		if now.Sub(e.Value.(*memoryIdempotencyEntry).record.Created) < s.ttl {
+			return
+		}
+		s.remove(e)
+	}
+}
+
+func (s *MemoryIdempotencyStore) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.records, e.Value.(*memoryIdempotencyEntry).key)
+}
+
+// NewIdempotencyKey returns a random UUID to be used as the idempotency key.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("read random bytes: %v", err))
+	}
+	// version 4 and variant 10
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// requestIdempotent makes the request of the operation unless the idempotency key is already recorded,
+// in which case the recorded response is decoded into v instead. Requests without the key are made as usual.
+// The key is reserved before sending the request, so the concurrent and retried requests with the key are not sent
+// until the outcome of the first one is known. The reservation is released when the request is not sent or rejected,
+// and the request whose outcome is unknown is resent with the same key for the server to deduplicate it.
+func (a *apiClient) requestIdempotent(req *http.Request, op Operation, key string, v any) error {
+	if key == "" {
+		return a.request(req, v)
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+	if a.idempotencyStore == nil {
+		return a.request(req, v)
+	}
+	ctx := req.Context()
+	// resent is true when the earlier request with the key may be processed
+	var resent bool
+	// the records of the key keep the time of the reservation, so that they expire in the order of the reservation
+	created := time.Now()
+
+	record, reserved, err := a.idempotencyStore.Reserve(ctx, key, &IdempotencyRecord{Operation: op, Status: IdempotencyStatusPending, Created: created})
+	if err != nil {
+		closeBody(req)
+		return fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if !reserved {
+		if record.Operation != op {
+			closeBody(req)
+			return fmt.Errorf("idempotency key %q is already used for %s", key, record.Operation)
+		}
+		switch record.Status {
+		case IdempotencyStatusCompleted:
+			closeBody(req)
+			if err := json.Unmarshal(record.Response, v); err != nil {
+				return fmt.Errorf("failed to decode recorded response: %w", err)
+			}
+			return nil
+		case IdempotencyStatusPending:
+			closeBody(req)
+			return fmt.Errorf("idempotency key %q: %w", key, ErrIdempotencyKeyPending)
+		default:
+			// the earlier request may be processed, so it's resent with the key instead of being reported as failed
+			created, resent = record.Created, true
+			pending := &IdempotencyRecord{Operation: op, Status: IdempotencyStatusPending, Created: created}
+			if err := a.idempotencyStore.Put(ctx, key, pending); err != nil {
+				closeBody(req)
+				return fmt.Errorf("failed to put idempotency record: %w", err)
+			}
+		}
+	}
+
+	// sent is set once the headers including the key are written to any connection
+	var sent atomic.Bool
+	req = req.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteHeaders: func() { sent.Store(true) },
+	}))
+	if err := a.request(req, v); err != nil {
+		// the store is updated regardless of the context of the request which may be done
+		if isRejected(err) || !sent.Load() && !resent {
+			if deleteErr := a.idempotencyStore.Delete(context.Background(), key); deleteErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to release idempotency key: %w", deleteErr))
+			}
+			return err
+		}
+		unknown := &IdempotencyRecord{Operation: op, Status: IdempotencyStatusUnknown, Created: created}
+		if putErr := a.idempotencyStore.Put(context.Background(), key, unknown); putErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to put idempotency record: %w", putErr))
+		}
+		return err
+	}
+	response, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	// the operation succeeded but a retry with the key would resubmit it, so the failure is reported
+	completed := &IdempotencyRecord{Operation: op, Status: IdempotencyStatusCompleted, Response: response, Created: created}
+	if err := a.idempotencyStore.Put(context.Background(), key, completed); err != nil {
+		return fmt.Errorf("failed to put idempotency record: %w", err)
+	}
+	return nil
+}
+
+// isRejected reports whether the request is rejected by the client error (4xx), so it's not processed by the server.
+func isRejected(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
@@ -0,0 +1,264 @@
+package saia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_personAPI_CreatePersonWithImages_Idempotency(t *testing.T) {
+	t.Parallel()
+
+	var gotKeys []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKeys = append(gotKeys, r.Header.Get(IdempotencyKeyHeader))
+		fmt.Fprintf(w, `{"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b15%02d/"}`, len(gotKeys))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, idempotencyStore: NewMemoryIdempotencyStore()}}
+	ctx := context.Background()
+
+	create := func(key string) (*CreatePersonWithImagesResponse, error) {
+		return m.CreatePersonWithImages(ctx, &CreatePersonWithImagesParams{
+			Gender:         GenderMale,
+			Height:         180,
+			Weight:         75,
+			FrontImage:     bytes.NewReader([]byte("front")),
+			SideImage:      bytes.NewReader([]byte("side")),
+			PhotoFlowType:  PhotoFlowTypeFriend,
+			IdempotencyKey: key,
+		})
+	}
+
+	first, err := create("key-1")
+	if err != nil {
+		t.Fatalf("CreatePersonWithImages() error = %v", err)
+	}
+	retried, err := create("key-1")
+	if err != nil {
+		t.Fatalf("CreatePersonWithImages() retry error = %v", err)
+	}
+	if diff := cmp.Diff(retried, first); diff != "" {
+		t.Errorf("CreatePersonWithImages() retry (-got, +want)\n%s", diff)
+	}
+	other, err := create("key-2")
+	if err != nil {
+		t.Fatalf("CreatePersonWithImages() error = %v", err)
+	}
+	if other.TaskSetID == first.TaskSetID {
+		t.Errorf("CreatePersonWithImages() with another key returned the same task set %s", other.TaskSetID)
+	}
+	if diff := cmp.Diff(gotKeys, []string{"key-1", "key-2"}); diff != "" {
+		t.Errorf("sent keys (-got, +want)\n%s", diff)
+	}
+
+	_, err = m.CreatePerson(ctx, &CreatePersonParams{Gender: GenderMale, Height: 180, Weight: 75, IdempotencyKey: "key-1"})
+	if err == nil || !strings.Contains(err.Error(), "already used for CreatePersonWithImages") {
+		t.Errorf("CreatePerson() with the key of another operation error = %v", err)
+	}
+}
+
+func Test_personAPI_CreatePerson_IdempotencyFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		// delay delays the response beyond the timeout of the first request
+		delay    time.Duration
+		wantHits int32
+	}{
+		{
+			name:     "Server error is resent with the key",
+			status:   http.StatusServiceUnavailable,
+			wantHits: 2,
+		},
+		{
+			name:     "Timeout is resent with the key",
+			status:   http.StatusOK,
+			delay:    100 * time.Millisecond,
+			wantHits: 2,
+		},
+		{
+			name:     "Client error releases the key",
+			status:   http.StatusBadRequest,
+			wantHits: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu      sync.Mutex
+				gotKeys []string
+				hits    atomic.Int32
+			)
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				gotKeys = append(gotKeys, r.Header.Get(IdempotencyKeyHeader))
+				mu.Unlock()
+				if hits.Add(1) == 1 {
+					time.Sleep(tt.delay)
+					w.WriteHeader(tt.status)
+				}
+				fmt.Fprintln(w, `{"id": 1}`)
+			}))
+			defer s.Close()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, idempotencyStore: NewMemoryIdempotencyStore()}}
+			params := &CreatePersonParams{Gender: GenderMale, Height: 180, Weight: 75, IdempotencyKey: "key"}
+
+			ctx := context.Background()
+			if tt.delay > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.delay/10)
+				defer cancel()
+			}
+			if _, err := m.CreatePerson(ctx, params); err == nil {
+				t.Fatal("CreatePerson() error = nil")
+			}
+			if _, err := m.CreatePerson(context.Background(), params); err != nil {
+				t.Errorf("CreatePerson() retry error = %v", err)
+			}
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("requests = %d, want %d", got, tt.wantHits)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if diff := cmp.Diff(gotKeys, []string{"key", "key"}); diff != "" {
+				t.Errorf("sent keys (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_personAPI_CreatePerson_IdempotencyNotSent(t *testing.T) {
+	t.Parallel()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		apiHost string
+	}{
+		{name: "Context canceled", ctx: canceled, apiHost: "http://example.com"},
+		{name: "Dial failure", ctx: context.Background(), apiHost: closed.URL},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := NewMemoryIdempotencyStore()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: tt.apiHost, idempotencyStore: store}}
+			params := &CreatePersonParams{Gender: GenderMale, Height: 180, Weight: 75, IdempotencyKey: "key"}
+			if _, err := m.CreatePerson(tt.ctx, params); err == nil {
+				t.Fatal("CreatePerson() error = nil")
+			}
+			if got := store.Len(); got != 0 {
+				t.Errorf("store has %d records, want the reservation released", got)
+			}
+		})
+	}
+}
+
+func Test_personAPI_CreatePerson_IdempotencyConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+	received := make(chan struct{})
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		close(received)
+		<-release
+		fmt.Fprintln(w, `{"id": 1}`)
+	}))
+	defer s.Close()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, idempotencyStore: NewMemoryIdempotencyStore()}}
+	params := &CreatePersonParams{Gender: GenderMale, Height: 180, Weight: 75, IdempotencyKey: "key"}
+
+	firstDone := make(chan error)
+	go func() {
+		_, err := m.CreatePerson(context.Background(), params)
+		firstDone <- err
+	}()
+	<-received
+	if _, err := m.CreatePerson(context.Background(), params); !errors.Is(err, ErrIdempotencyKeyPending) {
+		t.Errorf("CreatePerson() while the first is in flight error = %v, want %v", err, ErrIdempotencyKeyPending)
+	}
+	close(release)
+	if err := <-firstDone; err != nil {
+		t.Fatalf("CreatePerson() error = %v", err)
+	}
+
+	got, err := m.CreatePerson(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreatePerson() after the first is completed error = %v", err)
+	}
+	if got.ID != 1 || hits.Load() != 1 {
+		t.Errorf("CreatePerson() = %+v with %d requests, want the recorded response with 1 request", got, hits.Load())
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptionTTL(time.Hour), MemoryIdempotencyStoreOptionMaxSize(2))
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	reserve := func(key string) bool {
+		t.Helper()
+		_, reserved, err := store.Reserve(ctx, key, &IdempotencyRecord{Operation: OperationCreatePerson, Status: IdempotencyStatusPending, Created: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reserved
+	}
+
+	if !reserve("a") || reserve("a") {
+		t.Errorf("Reserve() reserved the key twice")
+	}
+	now = now.Add(30 * time.Minute)
+	reserve("b")
+	// the oldest record is evicted beyond the max size
+	reserve("c")
+	if store.Len() != 2 || !reserve("a") {
+		t.Errorf("Reserve() didn't evict the oldest record")
+	}
+	// the records expire after the TTL
+	now = now.Add(time.Hour)
+	if !reserve("b") || store.Len() != 1 {
+		t.Errorf("Reserve() didn't evict the expired records, len = %d", store.Len())
+	}
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	key := NewIdempotencyKey()
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(key) {
+		t.Errorf("NewIdempotencyKey() = %s, want UUID v4", key)
+	}
+	if NewIdempotencyKey() == key {
+		t.Errorf("NewIdempotencyKey() returned the same key")
+	}
+}
@@ -0,0 +1,266 @@
+// Package ingest creates persons from folders of front and side photos in bulk.
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shing-dev/saia-go"
+)
+
+// Options is the configuration of Ingester.
+type Options struct {
+	// Concurrency is the max number of subjects processed at the same time
+	Concurrency int
+	// PollInterval is the interval to poll the task set
+	PollInterval time.Duration
+	// StatePath is the path of the state file to resume a crashed run
+	// state is not persisted when it's empty
+	StatePath string
+}
+
+func newDefaultOptions() *Options {
+	return &Options{
+		Concurrency:  4,
+		PollInterval: 3 * time.Second,
+	}
+}
+
+// Option is a option to change ingester configuration.
+type Option func(*Options)
+
+func WithConcurrency(concurrency int) Option {
+	return func(o *Options) {
+		o.Concurrency = concurrency
+	}
+}
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.PollInterval = interval
+	}
+}
+
+func WithStatePath(path string) Option {
+	return func(o *Options) {
+		o.StatePath = path
+	}
+}
+
+// Result is the outcome of a subject written to the output as a JSON line.
+type Result struct {
+	SubjectID string          `json:"subject_id"`
+	TaskSetID string          `json:"task_set_id,omitempty"`
+	Status    saia.TaskStatus `json:"status"`
+	Error     string          `json:"error,omitempty"`
+	// Retryable is true when the subject failed with a transient error, e.g. a network error or 5xx,
+	// and it's submitted again by the next run
+	Retryable bool          `json:"retryable,omitempty"`
+	TaskSet   *saia.TaskSet `json:"task_set,omitempty"`
+	Person    *saia.Person  `json:"person,omitempty"`
+}
+
+// Summary is the summary of an ingestion run.
+type Summary struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	// Retryable is the number of the failed subjects which are submitted again by the next run
+	Retryable int
+	// Skipped is the number of subjects completed in the previous runs
+	Skipped int
+}
+
+// Ingester submits subjects to SAIA and waits for their calculations.
+type Ingester struct {
+	personAPI saia.PersonAPI
+	opts      *Options
+}
+
+// New creates a new Ingester.
+func New(personAPI saia.PersonAPI, opt ...Option) *Ingester {
+	opts := newDefaultOptions()
+	for _, o := range opt {
+		o(opts)
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	return &Ingester{
+		personAPI: personAPI,
+		opts:      opts,
+	}
+}
+
+// Run creates persons of the subjects with bounded concurrency and writes the results to w as JSON lines.
+// Subjects already completed according to the state file are skipped,
+// and subjects submitted but not completed are resumed without re-submitting them.
+func (i *Ingester) Run(ctx context.Context, subjects []*Subject, w io.Writer) (*Summary, error) {
+	st, err := openState(i.opts.StatePath)
+	if err != nil {
+		return nil, err
+	}
+	defer st.close()
+
+	var (
+		mu      sync.Mutex
+		summary = &Summary{Total: len(subjects)}
+		enc     = json.NewEncoder(w)
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, i.opts.Concurrency)
+		errOnce sync.Once
+		runErr  error
+	)
+	for _, subject := range subjects {
+		if entry, ok := st.get(subject.ID); ok && entry.Status == subjectStatusCompleted {
+			summary.Skipped++
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return summary, ctx.Err()
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(subject *Subject) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := i.process(ctx, st, subject)
+			if err != nil {
+				// the subject is left in the state as is, so it's retried in the next run
+				errOnce.Do(func() { runErr = err })
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if result.Status == saia.TaskStatusSuccess {
+				summary.Succeeded++
+			} else {
+				summary.Failed++
+			}
+			if result.Retryable {
+				summary.Retryable++
+			}
+			if err := enc.Encode(result); err != nil {
+				errOnce.Do(func() { runErr = fmt.Errorf("write result: %w", err) })
+				return
+			}
+			if result.Retryable {
+				return
+			}
+			if err := st.put(&stateEntry{SubjectID: subject.ID, TaskSetID: result.TaskSetID, Status: subjectStatusCompleted}); err != nil {
+				errOnce.Do(func() { runErr = err })
+			}
+		}(subject)
+	}
+	wg.Wait()
+
+	return summary, runErr
+}
+
+// process submits the subject unless it's already submitted and waits for the task set.
+// Failures of the subject itself (e.g. invalid images) are reported in the result, not as error.
+// Failures of the submission which may succeed on retry are reported as retryable.
+func (i *Ingester) process(ctx context.Context, st *state, subject *Subject) (*Result, error) {
+	result := &Result{SubjectID: subject.ID}
+
+	taskSetID := ""
+	if entry, ok := st.get(subject.ID); ok && entry.Status == subjectStatusSubmitted {
+		taskSetID = entry.TaskSetID
+	} else {
+		resp, err := i.submit(ctx, subject)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.Status = saia.TaskStatusFailure
+			result.Error = err.Error()
+			result.Retryable = !isPermanent(err)
+			return result, nil
+		}
+		taskSetID = resp.TaskSetID
+		if err := st.put(&stateEntry{SubjectID: subject.ID, TaskSetID: taskSetID, Status: subjectStatusSubmitted}); err != nil {
+			return nil, err
+		}
+	}
+	result.TaskSetID = taskSetID
+
+	resp, err := i.waitForTaskSet(ctx, taskSetID)
+	if resp == nil {
+		return nil, fmt.Errorf("wait for task set of subject %q: %w", subject.ID, err)
+	}
+	if err != nil {
+		result.Status = saia.TaskStatusFailure
+		result.TaskSet = resp.TaskSet
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Status = saia.TaskStatusSuccess
+	result.Person = resp.Person
+	return result, nil
+}
+
+func (i *Ingester) submit(ctx context.Context, subject *Subject) (*saia.CreatePersonWithImagesResponse, error) {
+	frontImage, err := os.Open(subject.FrontImagePath)
+	if err != nil {
+		return nil, fmt.Errorf("open front image: %w", err)
+	}
+	defer frontImage.Close()
+	sideImage, err := os.Open(subject.SideImagePath)
+	if err != nil {
+		return nil, fmt.Errorf("open side image: %w", err)
+	}
+	defer sideImage.Close()
+
+	return i.personAPI.CreatePersonWithImages(ctx, subject.params(frontImage, sideImage))
+}
+
+// waitForTaskSet waits for the task set, polling again on the transient errors until ctx is done,
+// since the task set keeps being processed by SAIA while the polling fails.
+func (i *Ingester) waitForTaskSet(ctx context.Context, taskSetID string) (*saia.GetTaskSetResponse, error) {
+	for {
+		resp, err := saia.WaitForTaskSet(ctx, i.personAPI, taskSetID, i.opts.PollInterval)
+		if resp != nil || ctx.Err() != nil || !isTransient(err) {
+			return resp, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(i.opts.PollInterval):
+		}
+	}
+}
+
+// isTransient reports whether the same request may succeed later, e.g. timed out or the server is unavailable.
+func isTransient(err error) bool {
+	if errors.Is(err, saia.ErrTimeout) || errors.Is(err, saia.ErrCircuitOpen) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var apiErr *saia.APIError
+	return errors.As(err, &apiErr) && !apiErr.IsPermanent()
+}
+
+// isPermanent reports whether submitting the subject again fails with the same error.
+func isPermanent(err error) bool {
+	var verr *saia.ValidationError
+	if errors.As(err, &verr) {
+		return true
+	}
+	var apiErr *saia.APIError
+	return errors.As(err, &apiErr) && apiErr.IsPermanent()
+}
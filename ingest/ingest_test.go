@@ -0,0 +1,274 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shing-dev/saia-go"
+)
+
+type fakePersonAPI struct {
+	saia.PersonAPI
+
+	mu        sync.Mutex
+	submitted []int
+	// errs is the errors of the submissions by height
+	errs map[int]error
+	// taskSetErrs is the errors returned by GetTaskSet in order before the task set is returned
+	taskSetErrs []error
+}
+
+func (f *fakePersonAPI) CreatePersonWithImages(ctx context.Context, params *saia.CreatePersonWithImagesParams) (*saia.CreatePersonWithImagesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.submitted = append(f.submitted, params.Height)
+	if err := f.errs[params.Height]; err != nil {
+		return nil, err
+	}
+	return &saia.CreatePersonWithImagesResponse{TaskSetID: "task-set-" + string(params.Gender)}, nil
+}
+
+func (f *fakePersonAPI) GetTaskSet(ctx context.Context, taskSetID string) (*saia.GetTaskSetResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.taskSetErrs) > 0 {
+		err := f.taskSetErrs[0]
+		f.taskSetErrs = f.taskSetErrs[1:]
+		return nil, err
+	}
+	if taskSetID == "task-set-female" {
+		return &saia.GetTaskSetResponse{TaskSet: &saia.TaskSet{IsReady: true}}, nil
+	}
+	return &saia.GetTaskSetResponse{Person: &saia.Person{ID: 1}}, nil
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScanDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a/front.jpg":   "",
+		"a/side.JPG":    "",
+		"b_front.png":   "",
+		"b_side.png":    "",
+		"ignored.txt":   "",
+		"c/notes.jpeg":  "",
+		"d/e/front.jpg": "",
+		"d/e/side.jpg":  "",
+	})
+
+	got, err := ScanDir(dir, SubjectDefaults{Gender: saia.GenderMale, Height: 170})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Subject{
+		{ID: "a", Gender: saia.GenderMale, Height: 170, FrontImagePath: filepath.Join(dir, "a/front.jpg"), SideImagePath: filepath.Join(dir, "a/side.JPG")},
+		{ID: "b", Gender: saia.GenderMale, Height: 170, FrontImagePath: filepath.Join(dir, "b_front.png"), SideImagePath: filepath.Join(dir, "b_side.png")},
+		{ID: "d/e", Gender: saia.GenderMale, Height: 170, FrontImagePath: filepath.Join(dir, "d/e/front.jpg"), SideImagePath: filepath.Join(dir, "d/e/side.jpg")},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ScanDir() (-got, +want)\n%s", diff)
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a/front.jpg": "",
+		"a/side.jpg":  "",
+		"x.jpg":       "",
+		"y.jpg":       "",
+		"manifest.csv": "id,gender,height,weight,front_image,side_image\n" +
+			"a,Male,180,75.5,,\n" +
+			"b,female,160,50,x.jpg,y.jpg\n",
+	})
+
+	got, err := LoadManifest(filepath.Join(dir, "manifest.csv"), SubjectDefaults{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Subject{
+		{ID: "a", Gender: saia.GenderMale, Height: 180, Weight: 75.5, FrontImagePath: filepath.Join(dir, "a/front.jpg"), SideImagePath: filepath.Join(dir, "a/side.jpg")},
+		{ID: "b", Gender: saia.GenderFemale, Height: 160, Weight: 50, FrontImagePath: filepath.Join(dir, "x.jpg"), SideImagePath: filepath.Join(dir, "y.jpg")},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("LoadManifest() (-got, +want)\n%s", diff)
+	}
+}
+
+func TestIngester_Run(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a/front.jpg": "",
+		"a/side.jpg":  "",
+		"b/front.jpg": "",
+		"b/side.jpg":  "",
+		"c/front.jpg": "",
+		"c/side.jpg":  "",
+	})
+	subjects := []*Subject{
+		{ID: "a", Gender: saia.GenderMale, Height: 1, FrontImagePath: filepath.Join(dir, "a/front.jpg"), SideImagePath: filepath.Join(dir, "a/side.jpg")},
+		{ID: "b", Gender: saia.GenderFemale, Height: 2, FrontImagePath: filepath.Join(dir, "b/front.jpg"), SideImagePath: filepath.Join(dir, "b/side.jpg")},
+		{ID: "c", Gender: saia.GenderMale, Height: 3, FrontImagePath: filepath.Join(dir, "c/front.jpg"), SideImagePath: filepath.Join(dir, "c/side.jpg")},
+	}
+	statePath := filepath.Join(dir, "state.jsonl")
+	// "a" is completed and "b" is submitted in the previous run
+	writeFiles(t, dir, map[string]string{
+		"state.jsonl": `{"subject_id":"a","task_set_id":"task-set-male","status":"submitted"}
+{"subject_id":"a","task_set_id":"task-set-male","status":"completed"}
+{"subject_id":"b","task_set_id":"task-set-female","status":"submitted"}
+{"subject_id":"c","task_set_`,
+	})
+
+	personAPI := &fakePersonAPI{}
+	ingester := New(personAPI, WithConcurrency(2), WithPollInterval(time.Millisecond), WithStatePath(statePath))
+	var out bytes.Buffer
+	summary, err := ingester.Run(context.Background(), subjects, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(summary, &Summary{Total: 3, Succeeded: 1, Failed: 1, Skipped: 1}); diff != "" {
+		t.Errorf("Run() summary (-got, +want)\n%s", diff)
+	}
+	if diff := cmp.Diff(personAPI.submitted, []int{3}); diff != "" {
+		t.Errorf("Run() submitted (-got, +want)\n%s", diff)
+	}
+
+	results := map[string]*Result{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var result Result
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		results[result.SubjectID] = &result
+	}
+	if len(results) != 2 || results["b"].Status != saia.TaskStatusFailure || results["c"].Status != saia.TaskStatusSuccess {
+		t.Errorf("Run() unexpected results: %s", out.String())
+	}
+
+	st, err := openState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.close()
+	for _, id := range []string{"a", "b", "c"} {
+		if entry, ok := st.get(id); !ok || entry.Status != subjectStatusCompleted {
+			t.Errorf("subject %q is not completed in state", id)
+		}
+	}
+}
+
+func TestIngester_Run_Retryable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a/front.jpg": "",
+		"a/side.jpg":  "",
+		"b/front.jpg": "",
+		"b/side.jpg":  "",
+		"c/front.jpg": "",
+		"c/side.jpg":  "",
+	})
+	subjects := []*Subject{
+		{ID: "a", Gender: saia.GenderMale, Height: 1, FrontImagePath: filepath.Join(dir, "a/front.jpg"), SideImagePath: filepath.Join(dir, "a/side.jpg")},
+		{ID: "b", Gender: saia.GenderMale, Height: 2, FrontImagePath: filepath.Join(dir, "b/front.jpg"), SideImagePath: filepath.Join(dir, "b/side.jpg")},
+		{ID: "c", Gender: saia.GenderMale, Height: 3, FrontImagePath: filepath.Join(dir, "c/front.jpg"), SideImagePath: filepath.Join(dir, "c/side.jpg")},
+	}
+	statePath := filepath.Join(dir, "state.jsonl")
+	personAPI := &fakePersonAPI{errs: map[int]error{
+		1: errors.New("connection refused"),
+		2: &saia.APIError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"},
+		3: &saia.APIError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"},
+	}}
+
+	ingester := New(personAPI, WithPollInterval(time.Millisecond), WithStatePath(statePath))
+	summary, err := ingester.Run(context.Background(), subjects, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(summary, &Summary{Total: 3, Failed: 3, Retryable: 2}); diff != "" {
+		t.Errorf("Run() summary (-got, +want)\n%s", diff)
+	}
+
+	// the transient failures are submitted again by the next run
+	personAPI.errs = nil
+	summary, err = New(personAPI, WithPollInterval(time.Millisecond), WithStatePath(statePath)).Run(context.Background(), subjects, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(summary, &Summary{Total: 3, Succeeded: 2, Skipped: 1}); diff != "" {
+		t.Errorf("Run() summary of the next run (-got, +want)\n%s", diff)
+	}
+}
+
+func TestSubject_Validate(t *testing.T) {
+	t.Parallel()
+
+	subject := &Subject{ID: "a", Gender: saia.GenderMale, Height: 180, Weight: 75, PhotoFlowType: saia.PhotoFlowTypeFriend}
+	if err := subject.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	subject.PhotoFlowType = ""
+	var verr *saia.ValidationError
+	if err := subject.Validate(); !errors.As(err, &verr) {
+		t.Errorf("Validate() error = %v, want *saia.ValidationError", err)
+	}
+}
+
+func TestIngester_Run_PollingErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a/front.jpg": "",
+		"a/side.jpg":  "",
+	})
+	subjects := []*Subject{
+		{ID: "a", Gender: saia.GenderMale, Height: 1, FrontImagePath: filepath.Join(dir, "a/front.jpg"), SideImagePath: filepath.Join(dir, "a/side.jpg")},
+	}
+	// the task set is still processed while the polling fails transiently
+	personAPI := &fakePersonAPI{taskSetErrs: []error{
+		&saia.TimeoutError{Operation: saia.OperationGetTaskSet, Timeout: 10 * time.Second, Err: context.DeadlineExceeded},
+		&saia.APIError{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"},
+		saia.ErrCircuitOpen,
+	}}
+
+	summary, err := New(personAPI, WithPollInterval(time.Millisecond)).Run(context.Background(), subjects, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(summary, &Summary{Total: 1, Succeeded: 1}); diff != "" {
+		t.Errorf("Run() summary (-got, +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+type subjectStatus string
+
+const (
+	subjectStatusSubmitted subjectStatus = "submitted"
+	subjectStatusCompleted subjectStatus = "completed"
+)
+
+type stateEntry struct {
+	SubjectID string        `json:"subject_id"`
+	TaskSetID string        `json:"task_set_id"`
+	Status    subjectStatus `json:"status"`
+}
+
+// state records the progress of ingestion so that a crashed run can be resumed.
+// Entries are appended to the file as JSON lines and the last entry of each subject wins.
+type state struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]*stateEntry
+}
+
+func openState(path string) (*state, error) {
+	s := &state{entries: map[string]*stateEntry{}}
+	if path == "" {
+		return s, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		var entry stateEntry
+		// a partially written last line is skipped since the subject is resumed from the previous entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		s.entries[entry.SubjectID] = &entry
+	}
+
+	s.file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open state file: %w", err)
+	}
+	// terminate the partially written line so that it doesn't corrupt the next entry
+	if len(b) > 0 && b[len(b)-1] != '\n' {
+		if _, err := s.file.Write([]byte{'\n'}); err != nil {
+			s.file.Close()
+			return nil, fmt.Errorf("write state file: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *state) get(subjectID string) (*stateEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[subjectID]
+	return entry, ok
+}
+
+func (s *state) put(entry *stateEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[entry.SubjectID] = entry
+	if s.file == nil {
+		return nil
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	return s.file.Sync()
+}
+
+func (s *state) close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,247 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shing-dev/saia-go"
+)
+
+// Subject is a person to be created from a pair of front and side photos.
+type Subject struct {
+	// ID identifies the subject within the ingestion, e.g. the folder name
+	ID             string
+	Gender         saia.Gender
+	Height         int
+	Weight         float64
+	FrontImagePath string
+	SideImagePath  string
+	PhotoFlowType  saia.PhotoFlowType
+}
+
+// Validate validates the attributes of the subject with the client-side validation of saia.
+// It's useful to find the invalid subjects before submitting any of them.
+func (s *Subject) Validate() error {
+	// the images are opened when the subject is submitted
+	return s.params(strings.NewReader(""), strings.NewReader("")).Validate()
+}
+
+func (s *Subject) params(frontImage, sideImage io.Reader) *saia.CreatePersonWithImagesParams {
+	return &saia.CreatePersonWithImagesParams{
+		Gender:        s.Gender,
+		Height:        s.Height,
+		Weight:        s.Weight,
+		FrontImage:    frontImage,
+		SideImage:     sideImage,
+		PhotoFlowType: s.PhotoFlowType,
+	}
+}
+
+// SubjectDefaults is used to fill the attributes which can't be found in the directory or manifest.
+type SubjectDefaults struct {
+	Gender        saia.Gender
+	Height        int
+	Weight        float64
+	PhotoFlowType saia.PhotoFlowType
+}
+
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+// ScanDir walks the directory and returns the subjects found in it.
+// A subject is either a sub directory containing front.<ext> and side.<ext>,
+// or a pair of <id>_front.<ext> and <id>_side.<ext> files.
+func ScanDir(dir string, defaults SubjectDefaults) ([]*Subject, error) {
+	subjectsByID, err := scanDir(dir, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	subjects := make([]*Subject, 0, len(subjectsByID))
+	for _, subject := range subjectsByID {
+		if subject.FrontImagePath == "" || subject.SideImagePath == "" {
+			return nil, fmt.Errorf("subject %q doesn't have both front and side images", subject.ID)
+		}
+		subjects = append(subjects, subject)
+	}
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].ID < subjects[j].ID
+	})
+	return subjects, nil
+}
+
+func scanDir(dir string, defaults SubjectDefaults) (map[string]*Subject, error) {
+	subjectsByID := map[string]*Subject{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if !imageExtensions[ext] {
+			return nil
+		}
+
+		id, side, ok := parseImagePath(dir, path)
+		if !ok {
+			return nil
+		}
+		subject, ok := subjectsByID[id]
+		if !ok {
+			subject = newSubject(id, defaults)
+			subjectsByID[id] = subject
+		}
+		if side == "front" {
+			subject.FrontImagePath = path
+		} else {
+			subject.SideImagePath = path
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir: %w", err)
+	}
+	return subjectsByID, nil
+}
+
+// parseImagePath returns the subject id and which side the image is.
+func parseImagePath(root string, path string) (id string, side string, ok bool) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	for _, s := range []string{"front", "side"} {
+		if strings.EqualFold(name, s) {
+			rel, err := filepath.Rel(root, filepath.Dir(path))
+			if err != nil || rel == "." {
+				return "", "", false
+			}
+			return filepath.ToSlash(rel), s, true
+		}
+		if suffix := "_" + s; strings.HasSuffix(strings.ToLower(name), suffix) {
+			rel, err := filepath.Rel(root, filepath.Join(filepath.Dir(path), name[:len(name)-len(suffix)]))
+			if err != nil {
+				return "", "", false
+			}
+			return filepath.ToSlash(rel), s, true
+		}
+	}
+	return "", "", false
+}
+
+// LoadManifest reads the manifest CSV and returns the subjects in it.
+// The manifest must have a header with id column.
+// gender, height and weight columns are optional and filled by the defaults when they are missing or empty,
+// use Subject.Validate to check the attributes before submitting the subjects.
+// front_image, side_image and photo_flow columns are optional,
+// the images are looked up in the manifest directory by the subject id when they are not given.
+func LoadManifest(path string, defaults SubjectDefaults) ([]*Subject, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+
+	dir := filepath.Dir(path)
+	scannedByID, err := scanDir(dir, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read manifest header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.TrimSpace(strings.ToLower(h))] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, errors.New("manifest doesn't have id column")
+	}
+	get := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var subjects []*Subject
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read manifest line %d: %w", line, err)
+		}
+
+		subject := newSubject(get(record, "id"), defaults)
+		if subject.ID == "" {
+			return nil, fmt.Errorf("manifest line %d: id is empty", line)
+		}
+		if v := get(record, "gender"); v != "" {
+			subject.Gender = saia.Gender(strings.ToLower(v))
+		}
+		if v := get(record, "height"); v != "" {
+			height, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("manifest line %d: parse height: %w", line, err)
+			}
+			subject.Height = height
+		}
+		if v := get(record, "weight"); v != "" {
+			weight, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("manifest line %d: parse weight: %w", line, err)
+			}
+			subject.Weight = weight
+		}
+		if v := get(record, "photo_flow"); v != "" {
+			subject.PhotoFlowType = saia.PhotoFlowType(v)
+		}
+		if s, ok := scannedByID[subject.ID]; ok {
+			subject.FrontImagePath = s.FrontImagePath
+			subject.SideImagePath = s.SideImagePath
+		}
+		if v := get(record, "front_image"); v != "" {
+			subject.FrontImagePath = resolvePath(dir, v)
+		}
+		if v := get(record, "side_image"); v != "" {
+			subject.SideImagePath = resolvePath(dir, v)
+		}
+		if subject.FrontImagePath == "" || subject.SideImagePath == "" {
+			return nil, fmt.Errorf("manifest line %d: images of subject %q are not found", line, subject.ID)
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects, nil
+}
+
+func newSubject(id string, defaults SubjectDefaults) *Subject {
+	return &Subject{
+		ID:            id,
+		Gender:        defaults.Gender,
+		Height:        defaults.Height,
+		Weight:        defaults.Weight,
+		PhotoFlowType: defaults.PhotoFlowType,
+	}
+}
+
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
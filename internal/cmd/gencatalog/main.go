@@ -0,0 +1,199 @@
+// Command gencatalog generates the measurement catalog from the params structs in measurement.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type group struct {
+	name       string
+	structName string
+	source     string
+}
+
+var groups = []*group{
+	{name: "front", structName: "FrontParams", source: "the front photo"},
+	{name: "side", structName: "SideParams", source: "the side photo"},
+	{name: "volume", structName: "VolumeParams", source: "the 3D body model"},
+}
+
+// descriptions are the hand written descriptions of the measurements
+// the others are described from their name, kind and source.
+var descriptions = map[string]string{
+	"front.body_height":          "Height of the body from the floor to the top of the head.",
+	"front.inseam":               "Length from the crotch to the floor along the inside of the leg.",
+	"front.outseam":              "Length from the waist to the floor along the outside of the leg.",
+	"front.sleeve_length":        "Length from the shoulder point to the wrist along the arm.",
+	"front.shoulders":            "Width between the left and right shoulder points.",
+	"front.shoulder_slope":       "Angle of the shoulder line from the horizontal.",
+	"front.body_area_percentage": "Percentage of the photo area occupied by the body.",
+	"front.jacket_length":        "Length from the side neck point to the bottom of a jacket.",
+	"side.body_area_percentage":  "Percentage of the photo area occupied by the body.",
+	"volume.chest":               "Girth around the fullest part of the chest.",
+	"volume.under_bust_girth":    "Girth around the torso directly below the bust.",
+	"volume.waist":               "Girth around the natural waist line.",
+	"volume.high_hips":           "Girth around the hips at the upper hip bone level.",
+	"volume.low_hips":            "Girth around the fullest part of the hips.",
+	"volume.neck":                "Girth around the base of the neck.",
+	"volume.thigh":               "Girth around the fullest part of the thigh.",
+}
+
+type measurement struct {
+	key       string
+	constName string
+	jsonKey   string
+	fieldName string
+	group     *group
+	kind      string
+}
+
+func main() {
+	input := flag.String("i", "measurement.go", "input file which defines the params structs")
+	output := flag.String("o", "measurement_catalog_gen.go", "output file")
+	flag.Parse()
+
+	measurements, err := parse(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(measurements)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parse(filename string) (map[string][]*measurement, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filename, err)
+	}
+	structs := map[string]*ast.StructType{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok {
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				structs[ts.Name.Name] = st
+			}
+		}
+		return true
+	})
+
+	measurements := map[string][]*measurement{}
+	for _, g := range groups {
+		st, ok := structs[g.structName]
+		if !ok {
+			return nil, fmt.Errorf("struct %s is not found", g.structName)
+		}
+		for _, field := range st.Fields.List {
+			if ident, ok := field.Type.(*ast.Ident); !ok || ident.Name != "float64" || field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			jsonKey, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+			if jsonKey == "" || jsonKey == "-" {
+				continue
+			}
+			for _, name := range field.Names {
+				measurements[g.name] = append(measurements[g.name], &measurement{
+					key:       g.name + "." + jsonKey,
+					constName: "MeasurementKey" + title(g.name) + name.Name,
+					jsonKey:   jsonKey,
+					fieldName: name.Name,
+					group:     g,
+					kind:      kindOf(g.name, jsonKey),
+				})
+			}
+		}
+	}
+	return measurements, nil
+}
+
+func kindOf(group, jsonKey string) string {
+	switch {
+	case strings.Contains(jsonKey, "percentage"):
+		return "Percentage"
+	case strings.Contains(jsonKey, "slope"):
+		return "Angle"
+	case group == "volume":
+		return "Girth"
+	case strings.HasSuffix(jsonKey, "_height"):
+		return "Height"
+	case strings.Contains(jsonKey, "width") || jsonKey == "shoulders" || jsonKey == "legs_distance":
+		return "Width"
+	default:
+		return "Length"
+	}
+}
+
+func title(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// label returns the human readable name of the measurement from its JSON key.
+func label(jsonKey string) string {
+	s := strings.ReplaceAll(jsonKey, "_1_5_", "_1.5_")
+	return title(strings.ReplaceAll(s, "_", " "))
+}
+
+func description(m *measurement) string {
+	if d, ok := descriptions[m.key]; ok {
+		return d
+	}
+	return fmt.Sprintf("%s calculated from %s.", label(m.jsonKey), m.group.source)
+}
+
+func generate(measurements map[string][]*measurement) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by gencatalog. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package saia")
+	fmt.Fprintln(&buf)
+
+	fmt.Fprintln(&buf, "const (")
+	for _, g := range groups {
+		for _, m := range measurements[g.name] {
+			fmt.Fprintf(&buf, "%s MeasurementKey = %q\n", m.constName, m.key)
+		}
+	}
+	fmt.Fprintln(&buf, ")")
+	fmt.Fprintln(&buf)
+
+	fmt.Fprintln(&buf, "var measurementCatalog = []*MeasurementInfo{")
+	for _, g := range groups {
+		for _, m := range measurements[g.name] {
+			fmt.Fprintf(&buf, "{Key: %s, JSONKey: %q, Group: MeasurementGroup%s, Kind: MeasurementKind%s, Name: %q, Description: %q},\n",
+				m.constName, m.jsonKey, title(g.name), m.kind, label(m.jsonKey), description(m))
+		}
+	}
+	fmt.Fprintln(&buf, "}")
+
+	for _, g := range groups {
+		fmt.Fprintln(&buf)
+		fmt.Fprintf(&buf, "func (p *%s) get(key MeasurementKey) (float64, bool) {\n", g.structName)
+		fmt.Fprintln(&buf, "switch key {")
+		for _, m := range measurements[g.name] {
+			fmt.Fprintf(&buf, "case %s:\nreturn p.%s, true\n", m.constName, m.fieldName)
+		}
+		fmt.Fprintln(&buf, "default:\nreturn 0, false")
+		fmt.Fprintln(&buf, "}")
+		fmt.Fprintln(&buf, "}")
+	}
+
+	return format.Source(buf.Bytes())
+}
@@ -13,6 +13,22 @@ const (
 	MeasurementStatusFailed  MeasurementStatus = "failed"
 )
 
+// MeasurementUnit is the unit of measurements shown to the customer in the widget.
+type MeasurementUnit string
+
+const (
+	MeasurementUnitCentimeter MeasurementUnit = "cm"
+	MeasurementUnitInch       MeasurementUnit = "in"
+)
+
+// NotificationMethod is how the widget link is sent to the customer.
+type NotificationMethod string
+
+const (
+	NotificationMethodEmail NotificationMethod = "email"
+	NotificationMethodSMS   NotificationMethod = "sms"
+)
+
 type Measurement struct {
 	ID                 int               `json:"id"`
 	UUID               string            `json:"uuid"`
@@ -177,11 +193,11 @@ type Measurement struct {
 	} `json:"email_message_data"`
 	SmsMessageData struct {
 	} `json:"sms_message_data"`
-	Source     string `json:"source"`
-	Unit       string `json:"unit"`
-	Notes      string `json:"notes"`
-	IsViewed   bool   `json:"is_viewed"`
-	IsArchived bool   `json:"is_archived"`
+	Source     string          `json:"source"`
+	Unit       MeasurementUnit `json:"unit"`
+	Notes      string          `json:"notes"`
+	IsViewed   bool            `json:"is_viewed"`
+	IsArchived bool            `json:"is_archived"`
 	Person     struct {
 		ID            int       `json:"id"`
 		URL           string    `json:"url"`
@@ -201,39 +217,22 @@ type Measurement struct {
 				GammaY float64 `json:"gammaY"`
 			} `json:"frontPhoto"`
 		} `json:"phone_position"`
-		PhotoFlow   string `json:"photo_flow"`
-		IPAddress   string `json:"ip_address"`
-		CountryName string `json:"country_name"`
-		CountryCode string `json:"country_code"`
-		IsViewed    bool   `json:"is_viewed"`
-		IsArchived  bool   `json:"is_archived"`
-		TaskSets    []struct {
-			ID           int           `json:"id"`
-			FrontParams  *FrontParams  `json:"front_params"`
-			SideParams   *SideParams   `json:"side_params"`
-			VolumeParams *VolumeParams `json:"volume_params"`
-			IsSuccessful bool          `json:"is_successful"`
-			IsReady      bool          `json:"is_ready"`
-			IsPrimary    bool          `json:"is_primary"`
-			SubTasks     []struct {
-				Name    string `json:"name"`
-				Status  string `json:"status"`
-				TaskID  string `json:"task_id"`
-				Message string `json:"message"`
-			} `json:"sub_tasks"`
-			Created time.Time `json:"created"`
-		} `json:"task_sets"`
+		PhotoFlow   string           `json:"photo_flow"`
+		IPAddress   string           `json:"ip_address"`
+		CountryName string           `json:"country_name"`
+		CountryCode string           `json:"country_code"`
+		IsViewed    bool             `json:"is_viewed"`
+		IsArchived  bool             `json:"is_archived"`
+		TaskSets    []*PersonTaskSet `json:"task_sets"`
 	} `json:"person"`
-	MtmClient struct {
-		ID        int       `json:"id"`
-		FirstName string    `json:"first_name"`
-		LastName  string    `json:"last_name"`
-		Email     string    `json:"email"`
-		Phone     string    `json:"phone"`
-		Notes     string    `json:"notes"`
-		Created   time.Time `json:"created"`
-	} `json:"mtm_client"`
-	IsDemoTry bool `json:"is_demo_try"`
+	MtmClient MtmClient `json:"mtm_client"`
+	IsDemoTry bool      `json:"is_demo_try"`
+}
+
+// PrimaryTaskSet returns the primary calculation of the measured person.
+// It falls back to the latest successful calculation when no calculation is marked as primary.
+func (m *Measurement) PrimaryTaskSet() *PersonTaskSet {
+	return primaryTaskSet(m.Person.TaskSets)
 }
 
 type PhonePosition struct {
@@ -1,7 +1,9 @@
 package saia
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -11,6 +13,18 @@ import (
 type MeasurementAPI interface {
 	GetMeasurementList(ctx context.Context, options ...GetMeasurementListOption) (*GetMeasurementListResponse, error)
 	GetMeasurement(ctx context.Context, measurementID int) (*Measurement, error)
+	GetMeasurements(ctx context.Context, measurementIDs []int, options ...BatchOption) []*MeasurementResult
+	CreateMeasurement(ctx context.Context, params *CreateMeasurementParams) (*Measurement, error)
+	ResendMeasurementLink(ctx context.Context, measurementID int, params *ResendMeasurementLinkParams) (*Measurement, error)
+	ArchiveMeasurement(ctx context.Context, measurementID int) (*Measurement, error)
+	UnarchiveMeasurement(ctx context.Context, measurementID int) (*Measurement, error)
+	MarkMeasurementViewed(ctx context.Context, measurementID int) (*Measurement, error)
+	UpdateMeasurementNotes(ctx context.Context, measurementID int, notes string) (*Measurement, error)
+	DeleteMeasurement(ctx context.Context, measurementID int) error
+	ArchiveMeasurements(ctx context.Context, measurementIDs []int) []*BulkResult
+	UnarchiveMeasurements(ctx context.Context, measurementIDs []int) []*BulkResult
+	MarkMeasurementsViewed(ctx context.Context, measurementIDs []int) []*BulkResult
+	DeleteMeasurements(ctx context.Context, measurementIDs []int) []*BulkResult
 }
 
 type measurementAPI struct {
@@ -91,10 +105,16 @@ type GetMeasurementListResponse struct {
 }
 
 func (m *measurementAPI) GetMeasurementList(ctx context.Context, options ...GetMeasurementListOption) (*GetMeasurementListResponse, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationGetMeasurementList)
+	defer cancel()
+
 	params := newGetMeasurementListParams()
 	for _, opt := range options {
 		opt(params)
 	}
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("failed to validate params: %w", err)
+	}
 
 	url, err := m.buildURL("/measurements/mtm-widgets/")
 	if err != nil {
@@ -115,6 +135,9 @@ func (m *measurementAPI) GetMeasurementList(ctx context.Context, options ...GetM
 }
 
 func (m *measurementAPI) GetMeasurement(ctx context.Context, measurementID int) (*Measurement, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationGetMeasurement)
+	defer cancel()
+
 	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-widgets/%d/", measurementID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build url: %w", err)
@@ -124,6 +147,126 @@ func (m *measurementAPI) GetMeasurement(ctx context.Context, measurementID int)
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	var measurement Measurement
+	if err := m.requestCached(req, measurementCacheKey(measurementID), &measurement, measurement.isTerminal); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &measurement, nil
+}
+
+// CreateMeasurementParams is the params to create a measurement and send the widget link to the customer
+// Either MtmClientID, Email or Phone must be set.
+type CreateMeasurementParams struct {
+	// MtmClientID is the id of the client to be measured
+	MtmClientID int `json:"mtm_client,omitempty"`
+	// Email is the email address to send the link when the customer is not a registered client
+	Email string `json:"email,omitempty"`
+	// Phone is the phone number to send the link when the customer is not a registered client
+	Phone              string             `json:"phone,omitempty"`
+	Unit               MeasurementUnit    `json:"unit,omitempty"`
+	NotificationMethod NotificationMethod `json:"notification_method,omitempty"`
+}
+
+func (m *measurementAPI) CreateMeasurement(ctx context.Context, params *CreateMeasurementParams) (*Measurement, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationCreateMeasurement)
+	defer cancel()
+
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("failed to validate params: %w", err)
+	}
+
+	url, err := m.buildURL("/measurements/mtm-widgets/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params to json: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var measurement Measurement
+	if err := m.request(req, &measurement); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &measurement, nil
+}
+
+type ResendMeasurementLinkParams struct {
+	// NotificationMethod overrides the notification method of the measurement if it's set
+	NotificationMethod NotificationMethod `json:"notification_method,omitempty"`
+}
+
+func (m *measurementAPI) ResendMeasurementLink(ctx context.Context, measurementID int, params *ResendMeasurementLinkParams) (*Measurement, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationResendMeasurementLink)
+	defer cancel()
+
+	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-widgets/%d/resend/", measurementID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	if params == nil {
+		params = &ResendMeasurementLinkParams{}
+	}
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("failed to validate params: %w", err)
+	}
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params to json: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var measurement Measurement
+	if err := m.request(req, &measurement); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &measurement, nil
+}
+
+func (m *measurementAPI) ArchiveMeasurement(ctx context.Context, measurementID int) (*Measurement, error) {
+	return m.partialUpdateMeasurement(ctx, measurementID, map[string]any{"is_archived": true})
+}
+
+func (m *measurementAPI) UnarchiveMeasurement(ctx context.Context, measurementID int) (*Measurement, error) {
+	return m.partialUpdateMeasurement(ctx, measurementID, map[string]any{"is_archived": false})
+}
+
+func (m *measurementAPI) MarkMeasurementViewed(ctx context.Context, measurementID int) (*Measurement, error) {
+	return m.partialUpdateMeasurement(ctx, measurementID, map[string]any{"is_viewed": true})
+}
+
+func (m *measurementAPI) UpdateMeasurementNotes(ctx context.Context, measurementID int, notes string) (*Measurement, error) {
+	return m.partialUpdateMeasurement(ctx, measurementID, map[string]any{"notes": notes})
+}
+
+func (m *measurementAPI) partialUpdateMeasurement(ctx context.Context, measurementID int, fields map[string]any) (*Measurement, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationUpdateMeasurement)
+	defer cancel()
+	defer m.invalidate(measurementCacheKey(measurementID))
+
+	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-widgets/%d/", measurementID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	reqBody, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params to json: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
 	var measurement Measurement
 	if err := m.request(req, &measurement); err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
@@ -131,3 +274,67 @@ func (m *measurementAPI) GetMeasurement(ctx context.Context, measurementID int)
 
 	return &measurement, nil
 }
+
+func (m *measurementAPI) DeleteMeasurement(ctx context.Context, measurementID int) error {
+	ctx, cancel := m.withTimeout(ctx, OperationDeleteMeasurement)
+	defer cancel()
+	defer m.invalidate(measurementCacheKey(measurementID))
+
+	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-widgets/%d/", measurementID))
+	if err != nil {
+		return fmt.Errorf("failed to build url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := m.request(req, nil); err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return nil
+}
+
+func (m *measurementAPI) ArchiveMeasurements(ctx context.Context, measurementIDs []int) []*BulkResult {
+	return bulk(ctx, measurementIDs, func(ctx context.Context, id int) error {
+		_, err := m.ArchiveMeasurement(ctx, id)
+		return err
+	})
+}
+
+func (m *measurementAPI) UnarchiveMeasurements(ctx context.Context, measurementIDs []int) []*BulkResult {
+	return bulk(ctx, measurementIDs, func(ctx context.Context, id int) error {
+		_, err := m.UnarchiveMeasurement(ctx, id)
+		return err
+	})
+}
+
+func (m *measurementAPI) MarkMeasurementsViewed(ctx context.Context, measurementIDs []int) []*BulkResult {
+	return bulk(ctx, measurementIDs, func(ctx context.Context, id int) error {
+		_, err := m.MarkMeasurementViewed(ctx, id)
+		return err
+	})
+}
+
+func (m *measurementAPI) DeleteMeasurements(ctx context.Context, measurementIDs []int) []*BulkResult {
+	return bulk(ctx, measurementIDs, m.DeleteMeasurement)
+}
+
+// MeasurementResult is the result of GetMeasurements for each measurement.
+type MeasurementResult struct {
+	ID          int
+	Measurement *Measurement
+	Err         error
+}
+
+// GetMeasurements gets the measurements concurrently and returns the results in the order of measurementIDs.
+// The failure of a measurement (e.g. not found) is reported in its result and doesn't stop the others.
+func (m *measurementAPI) GetMeasurements(ctx context.Context, measurementIDs []int, options ...BatchOption) []*MeasurementResult {
+	measurements, errs := batch(ctx, measurementIDs, options, m.GetMeasurement)
+	results := make([]*MeasurementResult, len(measurementIDs))
+	for i, id := range measurementIDs {
+		results[i] = &MeasurementResult{ID: id, Measurement: measurements[i], Err: errs[i]}
+	}
+	return results
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"github.com/google/go-cmp/cmp"
 	"github.com/shing-dev/saia-go/pkg/convutil"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_measurementAPI_GetMeasurementList(t *testing.T) {
@@ -139,6 +142,224 @@ func Test_measurementAPI_GetMeasurement(t *testing.T) {
 	}
 }
 
+func Test_measurementAPI_GetMeasurements(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/measurements/mtm-widgets/2/" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"detail": "Not found."}`)
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/measurements/mtm-widgets/"), "/")
+		fmt.Fprintf(w, `{"id": %s}`, id)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &measurementAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+	start := time.Now()
+	got := m.GetMeasurements(context.Background(), []int{3, 2, 1}, BatchOptionRateLimit(50))
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("GetMeasurements() took %v, want it rate limited to 50 requests per second", elapsed)
+	}
+
+	for i, wantID := range []int{3, 2, 1} {
+		r := got[i]
+		if r.ID != wantID {
+			t.Errorf("GetMeasurements()[%d].ID = %d, want %d", i, r.ID, wantID)
+		}
+		if wantErr := wantID == 2; (r.Err != nil) != wantErr {
+			t.Errorf("GetMeasurements()[%d].Err = %v, wantErr %v", i, r.Err, wantErr)
+		}
+		if r.Err == nil && r.Measurement.ID != wantID {
+			t.Errorf("GetMeasurements()[%d].Measurement.ID = %d, want %d", i, r.Measurement.ID, wantID)
+		}
+	}
+}
+
+func Test_measurementAPI_CreateMeasurement(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		params *CreateMeasurementParams
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *Measurement
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{
+				ctx: context.Background(),
+				params: &CreateMeasurementParams{
+					Email:              "john@example.com",
+					Unit:               MeasurementUnitInch,
+					NotificationMethod: NotificationMethodEmail,
+				},
+			},
+			resp: `{
+  "id": 1,
+  "uuid": "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+  "status": "pending",
+  "email": "john@example.com",
+  "unit": "in",
+  "short_link": "https://3dlook.me/s/abc"
+}`,
+			want: &Measurement{
+				ID:        1,
+				UUID:      "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+				Status:    MeasurementStatusPending,
+				Email:     "john@example.com",
+				Unit:      MeasurementUnitInch,
+				ShortLink: "https://3dlook.me/s/abc",
+			},
+		},
+		{
+			name:    "No recipient",
+			args:    args{ctx: context.Background(), params: &CreateMeasurementParams{Unit: MeasurementUnitCentimeter}},
+			wantErr: true,
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background(), params: &CreateMeasurementParams{MtmClientID: 1}},
+			resp:           `{"mtm_client": ["Invalid pk."]}`,
+			respStatusCode: 400,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 201
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockMeasurementAPI(t, tt.resp, statusCode)
+
+			got, err := m.CreateMeasurement(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateMeasurement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("CreateMeasurement() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_measurementAPI_ResendMeasurementLink(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx           context.Context
+		measurementID int
+		params        *ResendMeasurementLinkParams
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *Measurement
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{ctx: context.Background(), measurementID: 1, params: &ResendMeasurementLinkParams{NotificationMethod: NotificationMethodSMS}},
+			resp: `{"id": 1, "short_link": "https://3dlook.me/s/abc"}`,
+			want: &Measurement{ID: 1, ShortLink: "https://3dlook.me/s/abc"},
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background(), measurementID: 0},
+			resp:           `{"detail": "Not found."}`,
+			respStatusCode: 404,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 200
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockMeasurementAPI(t, tt.resp, statusCode)
+
+			got, err := m.ResendMeasurementLink(tt.args.ctx, tt.args.measurementID, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResendMeasurementLink() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("ResendMeasurementLink() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_measurementAPI_ArchiveMeasurement(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotBody string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotBody = r.Method, string(body)
+		fmt.Fprintln(w, `{"id": 1, "is_archived": true}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &measurementAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+	got, err := m.ArchiveMeasurement(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ArchiveMeasurement() error = %v", err)
+	}
+	if diff := cmp.Diff(got, &Measurement{ID: 1, IsArchived: true}); diff != "" {
+		t.Errorf("ArchiveMeasurement() (-got, +want)\n%s", diff)
+	}
+	if gotMethod != "PATCH" || gotBody != `{"is_archived":true}` {
+		t.Errorf("ArchiveMeasurement() sent %s %s", gotMethod, gotBody)
+	}
+}
+
+func Test_measurementAPI_DeleteMeasurements(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/measurements/mtm-widgets/2/" {
+			w.WriteHeader(404)
+			fmt.Fprintln(w, `{"detail": "Not found."}`)
+			return
+		}
+		w.WriteHeader(204)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &measurementAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+	results := m.DeleteMeasurements(context.Background(), []int{1, 2, 3})
+	if len(results) != 3 {
+		t.Fatalf("DeleteMeasurements() returned %d results, want 3", len(results))
+	}
+	for i, wantErr := range []bool{false, true, false} {
+		if results[i].ID != i+1 || (results[i].Err != nil) != wantErr {
+			t.Errorf("DeleteMeasurements() results[%d] = {%d, %v}, wantErr %v", i, results[i].ID, results[i].Err, wantErr)
+		}
+	}
+}
+
 func mockMeasurementAPI(t *testing.T, response string, status int) *measurementAPI {
 	t.Helper()
 
@@ -0,0 +1,382 @@
+// Code generated by gencatalog. DO NOT EDIT.
+
+package saia
+
+const (
+	MeasurementKeyFrontBodyAreaPercentage                     MeasurementKey = "front.body_area_percentage"
+	MeasurementKeyFrontBodyHeight                             MeasurementKey = "front.body_height"
+	MeasurementKeyFrontOutseam                                MeasurementKey = "front.outseam"
+	MeasurementKeyFrontOutseamFromUpperHipLevel               MeasurementKey = "front.outseam_from_upper_hip_level"
+	MeasurementKeyFrontInseam                                 MeasurementKey = "front.inseam"
+	MeasurementKeyFrontInsideLegLengthToThe1InchAboveTheFloor MeasurementKey = "front.inside_leg_length_to_the_1_inch_above_the_floor"
+	MeasurementKeyFrontInsideCrotchLengthToMidThigh           MeasurementKey = "front.inside_crotch_length_to_mid_thigh"
+	MeasurementKeyFrontInsideCrotchLengthToKnee               MeasurementKey = "front.inside_crotch_length_to_knee"
+	MeasurementKeyFrontInsideCrotchLengthToCalf               MeasurementKey = "front.inside_crotch_length_to_calf"
+	MeasurementKeyFrontCrotchLength                           MeasurementKey = "front.crotch_length"
+	MeasurementKeyFrontSleeveLength                           MeasurementKey = "front.sleeve_length"
+	MeasurementKeyFrontUnderarmLength                         MeasurementKey = "front.underarm_length"
+	MeasurementKeyFrontBackNeckPointToWristLength             MeasurementKey = "front.back_neck_point_to_wrist_length"
+	MeasurementKeyFrontBackNeckPointToWristLength15Inch       MeasurementKey = "front.back_neck_point_to_wrist_length_1_5_inch"
+	MeasurementKeyFrontHighHips                               MeasurementKey = "front.high_hips"
+	MeasurementKeyFrontShoulders                              MeasurementKey = "front.shoulders"
+	MeasurementKeyFrontChestTop                               MeasurementKey = "front.chest_top"
+	MeasurementKeyFrontJacketLength                           MeasurementKey = "front.jacket_length"
+	MeasurementKeyFrontShoulderLength                         MeasurementKey = "front.shoulder_length"
+	MeasurementKeyFrontShoulderSlope                          MeasurementKey = "front.shoulder_slope"
+	MeasurementKeyFrontNeck                                   MeasurementKey = "front.neck"
+	MeasurementKeyFrontWaistToLowHips                         MeasurementKey = "front.waist_to_low_hips"
+	MeasurementKeyFrontWaistToUpperKneeLength                 MeasurementKey = "front.waist_to_upper_knee_length"
+	MeasurementKeyFrontWaistToKnees                           MeasurementKey = "front.waist_to_knees"
+	MeasurementKeyFrontAbdomenToUpperKneeLength               MeasurementKey = "front.abdomen_to_upper_knee_length"
+	MeasurementKeyFrontUpperKneeToAnkle                       MeasurementKey = "front.upper_knee_to_ankle"
+	MeasurementKeyFrontNapeToWaistCentreBack                  MeasurementKey = "front.nape_to_waist_centre_back"
+	MeasurementKeyFrontShoulderToWaist                        MeasurementKey = "front.shoulder_to_waist"
+	MeasurementKeyFrontSideNeckPointToArmpit                  MeasurementKey = "front.side_neck_point_to_armpit"
+	MeasurementKeyFrontBackNeckHeight                         MeasurementKey = "front.back_neck_height"
+	MeasurementKeyFrontBustHeight                             MeasurementKey = "front.bust_height"
+	MeasurementKeyFrontHipHeight                              MeasurementKey = "front.hip_height"
+	MeasurementKeyFrontUpperHipHeight                         MeasurementKey = "front.upper_hip_height"
+	MeasurementKeyFrontKneeHeight                             MeasurementKey = "front.knee_height"
+	MeasurementKeyFrontOuterAnkleHeight                       MeasurementKey = "front.outer_ankle_height"
+	MeasurementKeyFrontWaistHeight                            MeasurementKey = "front.waist_height"
+	MeasurementKeyFrontInsideLegHeight                        MeasurementKey = "front.inside_leg_height"
+	MeasurementKeyFrontAcrossBackShoulderWidth                MeasurementKey = "front.across_back_shoulder_width"
+	MeasurementKeyFrontAcrossBackWidth                        MeasurementKey = "front.across_back_width"
+	MeasurementKeyFrontTotalCrotchLength                      MeasurementKey = "front.total_crotch_length"
+	MeasurementKeyFrontWaist                                  MeasurementKey = "front.waist"
+	MeasurementKeyFrontNeckLength                             MeasurementKey = "front.neck_length"
+	MeasurementKeyFrontUpperArmLength                         MeasurementKey = "front.upper_arm_length"
+	MeasurementKeyFrontLowerArmLength                         MeasurementKey = "front.lower_arm_length"
+	MeasurementKeyFrontUpperHipToHipLength                    MeasurementKey = "front.upper_hip_to_hip_length"
+	MeasurementKeyFrontBackShoulderWidth                      MeasurementKey = "front.back_shoulder_width"
+	MeasurementKeyFrontRise                                   MeasurementKey = "front.rise"
+	MeasurementKeyFrontBackNeckToHipLength                    MeasurementKey = "front.back_neck_to_hip_length"
+	MeasurementKeyFrontTorsoHeight                            MeasurementKey = "front.torso_height"
+	MeasurementKeyFrontFrontTorsoHeight                       MeasurementKey = "front.front_torso_height"
+	MeasurementKeyFrontFrontCrotchLength                      MeasurementKey = "front.front_crotch_length"
+	MeasurementKeyFrontBackCrotchLength                       MeasurementKey = "front.back_crotch_length"
+	MeasurementKeyFrontLegsDistance                           MeasurementKey = "front.legs_distance"
+	MeasurementKeySideBodyAreaPercentage                      MeasurementKey = "side.body_area_percentage"
+	MeasurementKeySideSideUpperHipLevelToKnee                 MeasurementKey = "side.side_upper_hip_level_to_knee"
+	MeasurementKeySideSideNeckPointToUpperHip                 MeasurementKey = "side.side_neck_point_to_upper_hip"
+	MeasurementKeySideNeckToChest                             MeasurementKey = "side.neck_to_chest"
+	MeasurementKeySideChestToWaist                            MeasurementKey = "side.chest_to_waist"
+	MeasurementKeySideWaistToAnkle                            MeasurementKey = "side.waist_to_ankle"
+	MeasurementKeySideShouldersToKnees                        MeasurementKey = "side.shoulders_to_knees"
+	MeasurementKeyVolumeChest                                 MeasurementKey = "volume.chest"
+	MeasurementKeyVolumeUnderBustGirth                        MeasurementKey = "volume.under_bust_girth"
+	MeasurementKeyVolumeUpperChestGirth                       MeasurementKey = "volume.upper_chest_girth"
+	MeasurementKeyVolumeOverarmGirth                          MeasurementKey = "volume.overarm_girth"
+	MeasurementKeyVolumeWaist                                 MeasurementKey = "volume.waist"
+	MeasurementKeyVolumeAlternativeWaistGirth                 MeasurementKey = "volume.alternative_waist_girth"
+	MeasurementKeyVolumeHighHips                              MeasurementKey = "volume.high_hips"
+	MeasurementKeyVolumeLowHips                               MeasurementKey = "volume.low_hips"
+	MeasurementKeyVolumeWaistGreen                            MeasurementKey = "volume.waist_green"
+	MeasurementKeyVolumeWaistGray                             MeasurementKey = "volume.waist_gray"
+	MeasurementKeyVolumePantWaist                             MeasurementKey = "volume.pant_waist"
+	MeasurementKeyVolumeBicep                                 MeasurementKey = "volume.bicep"
+	MeasurementKeyVolumeUpperBicepGirth                       MeasurementKey = "volume.upper_bicep_girth"
+	MeasurementKeyVolumeUpperKneeGirth                        MeasurementKey = "volume.upper_knee_girth"
+	MeasurementKeyVolumeKnee                                  MeasurementKey = "volume.knee"
+	MeasurementKeyVolumeAnkle                                 MeasurementKey = "volume.ankle"
+	MeasurementKeyVolumeWrist                                 MeasurementKey = "volume.wrist"
+	MeasurementKeyVolumeCalf                                  MeasurementKey = "volume.calf"
+	MeasurementKeyVolumeThigh                                 MeasurementKey = "volume.thigh"
+	MeasurementKeyVolumeThigh1InchBelowCrotch                 MeasurementKey = "volume.thigh_1_inch_below_crotch"
+	MeasurementKeyVolumeMidThighGirth                         MeasurementKey = "volume.mid_thigh_girth"
+	MeasurementKeyVolumeNeck                                  MeasurementKey = "volume.neck"
+	MeasurementKeyVolumeAbdomen                               MeasurementKey = "volume.abdomen"
+	MeasurementKeyVolumeArmscyeGirth                          MeasurementKey = "volume.armscye_girth"
+	MeasurementKeyVolumeNeckGirth                             MeasurementKey = "volume.neck_girth"
+	MeasurementKeyVolumeNeckGirthRelaxed                      MeasurementKey = "volume.neck_girth_relaxed"
+	MeasurementKeyVolumeForearm                               MeasurementKey = "volume.forearm"
+	MeasurementKeyVolumeElbowGirth                            MeasurementKey = "volume.elbow_girth"
+)
+
+var measurementCatalog = []*MeasurementInfo{
+	{Key: MeasurementKeyFrontBodyAreaPercentage, JSONKey: "body_area_percentage", Group: MeasurementGroupFront, Kind: MeasurementKindPercentage, Name: "Body area percentage", Description: "Percentage of the photo area occupied by the body."},
+	{Key: MeasurementKeyFrontBodyHeight, JSONKey: "body_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Body height", Description: "Height of the body from the floor to the top of the head."},
+	{Key: MeasurementKeyFrontOutseam, JSONKey: "outseam", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Outseam", Description: "Length from the waist to the floor along the outside of the leg."},
+	{Key: MeasurementKeyFrontOutseamFromUpperHipLevel, JSONKey: "outseam_from_upper_hip_level", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Outseam from upper hip level", Description: "Outseam from upper hip level calculated from the front photo."},
+	{Key: MeasurementKeyFrontInseam, JSONKey: "inseam", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Inseam", Description: "Length from the crotch to the floor along the inside of the leg."},
+	{Key: MeasurementKeyFrontInsideLegLengthToThe1InchAboveTheFloor, JSONKey: "inside_leg_length_to_the_1_inch_above_the_floor", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Inside leg length to the 1 inch above the floor", Description: "Inside leg length to the 1 inch above the floor calculated from the front photo."},
+	{Key: MeasurementKeyFrontInsideCrotchLengthToMidThigh, JSONKey: "inside_crotch_length_to_mid_thigh", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Inside crotch length to mid thigh", Description: "Inside crotch length to mid thigh calculated from the front photo."},
+	{Key: MeasurementKeyFrontInsideCrotchLengthToKnee, JSONKey: "inside_crotch_length_to_knee", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Inside crotch length to knee", Description: "Inside crotch length to knee calculated from the front photo."},
+	{Key: MeasurementKeyFrontInsideCrotchLengthToCalf, JSONKey: "inside_crotch_length_to_calf", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Inside crotch length to calf", Description: "Inside crotch length to calf calculated from the front photo."},
+	{Key: MeasurementKeyFrontCrotchLength, JSONKey: "crotch_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Crotch length", Description: "Crotch length calculated from the front photo."},
+	{Key: MeasurementKeyFrontSleeveLength, JSONKey: "sleeve_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Sleeve length", Description: "Length from the shoulder point to the wrist along the arm."},
+	{Key: MeasurementKeyFrontUnderarmLength, JSONKey: "underarm_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Underarm length", Description: "Underarm length calculated from the front photo."},
+	{Key: MeasurementKeyFrontBackNeckPointToWristLength, JSONKey: "back_neck_point_to_wrist_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Back neck point to wrist length", Description: "Back neck point to wrist length calculated from the front photo."},
+	{Key: MeasurementKeyFrontBackNeckPointToWristLength15Inch, JSONKey: "back_neck_point_to_wrist_length_1_5_inch", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Back neck point to wrist length 1.5 inch", Description: "Back neck point to wrist length 1.5 inch calculated from the front photo."},
+	{Key: MeasurementKeyFrontHighHips, JSONKey: "high_hips", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "High hips", Description: "High hips calculated from the front photo."},
+	{Key: MeasurementKeyFrontShoulders, JSONKey: "shoulders", Group: MeasurementGroupFront, Kind: MeasurementKindWidth, Name: "Shoulders", Description: "Width between the left and right shoulder points."},
+	{Key: MeasurementKeyFrontChestTop, JSONKey: "chest_top", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Chest top", Description: "Chest top calculated from the front photo."},
+	{Key: MeasurementKeyFrontJacketLength, JSONKey: "jacket_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Jacket length", Description: "Length from the side neck point to the bottom of a jacket."},
+	{Key: MeasurementKeyFrontShoulderLength, JSONKey: "shoulder_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Shoulder length", Description: "Shoulder length calculated from the front photo."},
+	{Key: MeasurementKeyFrontShoulderSlope, JSONKey: "shoulder_slope", Group: MeasurementGroupFront, Kind: MeasurementKindAngle, Name: "Shoulder slope", Description: "Angle of the shoulder line from the horizontal."},
+	{Key: MeasurementKeyFrontNeck, JSONKey: "neck", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Neck", Description: "Neck calculated from the front photo."},
+	{Key: MeasurementKeyFrontWaistToLowHips, JSONKey: "waist_to_low_hips", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Waist to low hips", Description: "Waist to low hips calculated from the front photo."},
+	{Key: MeasurementKeyFrontWaistToUpperKneeLength, JSONKey: "waist_to_upper_knee_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Waist to upper knee length", Description: "Waist to upper knee length calculated from the front photo."},
+	{Key: MeasurementKeyFrontWaistToKnees, JSONKey: "waist_to_knees", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Waist to knees", Description: "Waist to knees calculated from the front photo."},
+	{Key: MeasurementKeyFrontAbdomenToUpperKneeLength, JSONKey: "abdomen_to_upper_knee_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Abdomen to upper knee length", Description: "Abdomen to upper knee length calculated from the front photo."},
+	{Key: MeasurementKeyFrontUpperKneeToAnkle, JSONKey: "upper_knee_to_ankle", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Upper knee to ankle", Description: "Upper knee to ankle calculated from the front photo."},
+	{Key: MeasurementKeyFrontNapeToWaistCentreBack, JSONKey: "nape_to_waist_centre_back", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Nape to waist centre back", Description: "Nape to waist centre back calculated from the front photo."},
+	{Key: MeasurementKeyFrontShoulderToWaist, JSONKey: "shoulder_to_waist", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Shoulder to waist", Description: "Shoulder to waist calculated from the front photo."},
+	{Key: MeasurementKeyFrontSideNeckPointToArmpit, JSONKey: "side_neck_point_to_armpit", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Side neck point to armpit", Description: "Side neck point to armpit calculated from the front photo."},
+	{Key: MeasurementKeyFrontBackNeckHeight, JSONKey: "back_neck_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Back neck height", Description: "Back neck height calculated from the front photo."},
+	{Key: MeasurementKeyFrontBustHeight, JSONKey: "bust_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Bust height", Description: "Bust height calculated from the front photo."},
+	{Key: MeasurementKeyFrontHipHeight, JSONKey: "hip_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Hip height", Description: "Hip height calculated from the front photo."},
+	{Key: MeasurementKeyFrontUpperHipHeight, JSONKey: "upper_hip_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Upper hip height", Description: "Upper hip height calculated from the front photo."},
+	{Key: MeasurementKeyFrontKneeHeight, JSONKey: "knee_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Knee height", Description: "Knee height calculated from the front photo."},
+	{Key: MeasurementKeyFrontOuterAnkleHeight, JSONKey: "outer_ankle_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Outer ankle height", Description: "Outer ankle height calculated from the front photo."},
+	{Key: MeasurementKeyFrontWaistHeight, JSONKey: "waist_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Waist height", Description: "Waist height calculated from the front photo."},
+	{Key: MeasurementKeyFrontInsideLegHeight, JSONKey: "inside_leg_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Inside leg height", Description: "Inside leg height calculated from the front photo."},
+	{Key: MeasurementKeyFrontAcrossBackShoulderWidth, JSONKey: "across_back_shoulder_width", Group: MeasurementGroupFront, Kind: MeasurementKindWidth, Name: "Across back shoulder width", Description: "Across back shoulder width calculated from the front photo."},
+	{Key: MeasurementKeyFrontAcrossBackWidth, JSONKey: "across_back_width", Group: MeasurementGroupFront, Kind: MeasurementKindWidth, Name: "Across back width", Description: "Across back width calculated from the front photo."},
+	{Key: MeasurementKeyFrontTotalCrotchLength, JSONKey: "total_crotch_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Total crotch length", Description: "Total crotch length calculated from the front photo."},
+	{Key: MeasurementKeyFrontWaist, JSONKey: "waist", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Waist", Description: "Waist calculated from the front photo."},
+	{Key: MeasurementKeyFrontNeckLength, JSONKey: "neck_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Neck length", Description: "Neck length calculated from the front photo."},
+	{Key: MeasurementKeyFrontUpperArmLength, JSONKey: "upper_arm_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Upper arm length", Description: "Upper arm length calculated from the front photo."},
+	{Key: MeasurementKeyFrontLowerArmLength, JSONKey: "lower_arm_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Lower arm length", Description: "Lower arm length calculated from the front photo."},
+	{Key: MeasurementKeyFrontUpperHipToHipLength, JSONKey: "upper_hip_to_hip_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Upper hip to hip length", Description: "Upper hip to hip length calculated from the front photo."},
+	{Key: MeasurementKeyFrontBackShoulderWidth, JSONKey: "back_shoulder_width", Group: MeasurementGroupFront, Kind: MeasurementKindWidth, Name: "Back shoulder width", Description: "Back shoulder width calculated from the front photo."},
+	{Key: MeasurementKeyFrontRise, JSONKey: "rise", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Rise", Description: "Rise calculated from the front photo."},
+	{Key: MeasurementKeyFrontBackNeckToHipLength, JSONKey: "back_neck_to_hip_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Back neck to hip length", Description: "Back neck to hip length calculated from the front photo."},
+	{Key: MeasurementKeyFrontTorsoHeight, JSONKey: "torso_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Torso height", Description: "Torso height calculated from the front photo."},
+	{Key: MeasurementKeyFrontFrontTorsoHeight, JSONKey: "front_torso_height", Group: MeasurementGroupFront, Kind: MeasurementKindHeight, Name: "Front torso height", Description: "Front torso height calculated from the front photo."},
+	{Key: MeasurementKeyFrontFrontCrotchLength, JSONKey: "front_crotch_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Front crotch length", Description: "Front crotch length calculated from the front photo."},
+	{Key: MeasurementKeyFrontBackCrotchLength, JSONKey: "back_crotch_length", Group: MeasurementGroupFront, Kind: MeasurementKindLength, Name: "Back crotch length", Description: "Back crotch length calculated from the front photo."},
+	{Key: MeasurementKeyFrontLegsDistance, JSONKey: "legs_distance", Group: MeasurementGroupFront, Kind: MeasurementKindWidth, Name: "Legs distance", Description: "Legs distance calculated from the front photo."},
+	{Key: MeasurementKeySideBodyAreaPercentage, JSONKey: "body_area_percentage", Group: MeasurementGroupSide, Kind: MeasurementKindPercentage, Name: "Body area percentage", Description: "Percentage of the photo area occupied by the body."},
+	{Key: MeasurementKeySideSideUpperHipLevelToKnee, JSONKey: "side_upper_hip_level_to_knee", Group: MeasurementGroupSide, Kind: MeasurementKindLength, Name: "Side upper hip level to knee", Description: "Side upper hip level to knee calculated from the side photo."},
+	{Key: MeasurementKeySideSideNeckPointToUpperHip, JSONKey: "side_neck_point_to_upper_hip", Group: MeasurementGroupSide, Kind: MeasurementKindLength, Name: "Side neck point to upper hip", Description: "Side neck point to upper hip calculated from the side photo."},
+	{Key: MeasurementKeySideNeckToChest, JSONKey: "neck_to_chest", Group: MeasurementGroupSide, Kind: MeasurementKindLength, Name: "Neck to chest", Description: "Neck to chest calculated from the side photo."},
+	{Key: MeasurementKeySideChestToWaist, JSONKey: "chest_to_waist", Group: MeasurementGroupSide, Kind: MeasurementKindLength, Name: "Chest to waist", Description: "Chest to waist calculated from the side photo."},
+	{Key: MeasurementKeySideWaistToAnkle, JSONKey: "waist_to_ankle", Group: MeasurementGroupSide, Kind: MeasurementKindLength, Name: "Waist to ankle", Description: "Waist to ankle calculated from the side photo."},
+	{Key: MeasurementKeySideShouldersToKnees, JSONKey: "shoulders_to_knees", Group: MeasurementGroupSide, Kind: MeasurementKindLength, Name: "Shoulders to knees", Description: "Shoulders to knees calculated from the side photo."},
+	{Key: MeasurementKeyVolumeChest, JSONKey: "chest", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Chest", Description: "Girth around the fullest part of the chest."},
+	{Key: MeasurementKeyVolumeUnderBustGirth, JSONKey: "under_bust_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Under bust girth", Description: "Girth around the torso directly below the bust."},
+	{Key: MeasurementKeyVolumeUpperChestGirth, JSONKey: "upper_chest_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Upper chest girth", Description: "Upper chest girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeOverarmGirth, JSONKey: "overarm_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Overarm girth", Description: "Overarm girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeWaist, JSONKey: "waist", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Waist", Description: "Girth around the natural waist line."},
+	{Key: MeasurementKeyVolumeAlternativeWaistGirth, JSONKey: "alternative_waist_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Alternative waist girth", Description: "Alternative waist girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeHighHips, JSONKey: "high_hips", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "High hips", Description: "Girth around the hips at the upper hip bone level."},
+	{Key: MeasurementKeyVolumeLowHips, JSONKey: "low_hips", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Low hips", Description: "Girth around the fullest part of the hips."},
+	{Key: MeasurementKeyVolumeWaistGreen, JSONKey: "waist_green", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Waist green", Description: "Waist green calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeWaistGray, JSONKey: "waist_gray", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Waist gray", Description: "Waist gray calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumePantWaist, JSONKey: "pant_waist", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Pant waist", Description: "Pant waist calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeBicep, JSONKey: "bicep", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Bicep", Description: "Bicep calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeUpperBicepGirth, JSONKey: "upper_bicep_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Upper bicep girth", Description: "Upper bicep girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeUpperKneeGirth, JSONKey: "upper_knee_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Upper knee girth", Description: "Upper knee girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeKnee, JSONKey: "knee", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Knee", Description: "Knee calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeAnkle, JSONKey: "ankle", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Ankle", Description: "Ankle calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeWrist, JSONKey: "wrist", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Wrist", Description: "Wrist calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeCalf, JSONKey: "calf", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Calf", Description: "Calf calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeThigh, JSONKey: "thigh", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Thigh", Description: "Girth around the fullest part of the thigh."},
+	{Key: MeasurementKeyVolumeThigh1InchBelowCrotch, JSONKey: "thigh_1_inch_below_crotch", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Thigh 1 inch below crotch", Description: "Thigh 1 inch below crotch calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeMidThighGirth, JSONKey: "mid_thigh_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Mid thigh girth", Description: "Mid thigh girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeNeck, JSONKey: "neck", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Neck", Description: "Girth around the base of the neck."},
+	{Key: MeasurementKeyVolumeAbdomen, JSONKey: "abdomen", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Abdomen", Description: "Abdomen calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeArmscyeGirth, JSONKey: "armscye_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Armscye girth", Description: "Armscye girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeNeckGirth, JSONKey: "neck_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Neck girth", Description: "Neck girth calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeNeckGirthRelaxed, JSONKey: "neck_girth_relaxed", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Neck girth relaxed", Description: "Neck girth relaxed calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeForearm, JSONKey: "forearm", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Forearm", Description: "Forearm calculated from the 3D body model."},
+	{Key: MeasurementKeyVolumeElbowGirth, JSONKey: "elbow_girth", Group: MeasurementGroupVolume, Kind: MeasurementKindGirth, Name: "Elbow girth", Description: "Elbow girth calculated from the 3D body model."},
+}
+
+func (p *FrontParams) get(key MeasurementKey) (float64, bool) {
+	switch key {
+	case MeasurementKeyFrontBodyAreaPercentage:
+		return p.BodyAreaPercentage, true
+	case MeasurementKeyFrontBodyHeight:
+		return p.BodyHeight, true
+	case MeasurementKeyFrontOutseam:
+		return p.Outseam, true
+	case MeasurementKeyFrontOutseamFromUpperHipLevel:
+		return p.OutseamFromUpperHipLevel, true
+	case MeasurementKeyFrontInseam:
+		return p.Inseam, true
+	case MeasurementKeyFrontInsideLegLengthToThe1InchAboveTheFloor:
+		return p.InsideLegLengthToThe1InchAboveTheFloor, true
+	case MeasurementKeyFrontInsideCrotchLengthToMidThigh:
+		return p.InsideCrotchLengthToMidThigh, true
+	case MeasurementKeyFrontInsideCrotchLengthToKnee:
+		return p.InsideCrotchLengthToKnee, true
+	case MeasurementKeyFrontInsideCrotchLengthToCalf:
+		return p.InsideCrotchLengthToCalf, true
+	case MeasurementKeyFrontCrotchLength:
+		return p.CrotchLength, true
+	case MeasurementKeyFrontSleeveLength:
+		return p.SleeveLength, true
+	case MeasurementKeyFrontUnderarmLength:
+		return p.UnderarmLength, true
+	case MeasurementKeyFrontBackNeckPointToWristLength:
+		return p.BackNeckPointToWristLength, true
+	case MeasurementKeyFrontBackNeckPointToWristLength15Inch:
+		return p.BackNeckPointToWristLength15Inch, true
+	case MeasurementKeyFrontHighHips:
+		return p.HighHips, true
+	case MeasurementKeyFrontShoulders:
+		return p.Shoulders, true
+	case MeasurementKeyFrontChestTop:
+		return p.ChestTop, true
+	case MeasurementKeyFrontJacketLength:
+		return p.JacketLength, true
+	case MeasurementKeyFrontShoulderLength:
+		return p.ShoulderLength, true
+	case MeasurementKeyFrontShoulderSlope:
+		return p.ShoulderSlope, true
+	case MeasurementKeyFrontNeck:
+		return p.Neck, true
+	case MeasurementKeyFrontWaistToLowHips:
+		return p.WaistToLowHips, true
+	case MeasurementKeyFrontWaistToUpperKneeLength:
+		return p.WaistToUpperKneeLength, true
+	case MeasurementKeyFrontWaistToKnees:
+		return p.WaistToKnees, true
+	case MeasurementKeyFrontAbdomenToUpperKneeLength:
+		return p.AbdomenToUpperKneeLength, true
+	case MeasurementKeyFrontUpperKneeToAnkle:
+		return p.UpperKneeToAnkle, true
+	case MeasurementKeyFrontNapeToWaistCentreBack:
+		return p.NapeToWaistCentreBack, true
+	case MeasurementKeyFrontShoulderToWaist:
+		return p.ShoulderToWaist, true
+	case MeasurementKeyFrontSideNeckPointToArmpit:
+		return p.SideNeckPointToArmpit, true
+	case MeasurementKeyFrontBackNeckHeight:
+		return p.BackNeckHeight, true
+	case MeasurementKeyFrontBustHeight:
+		return p.BustHeight, true
+	case MeasurementKeyFrontHipHeight:
+		return p.HipHeight, true
+	case MeasurementKeyFrontUpperHipHeight:
+		return p.UpperHipHeight, true
+	case MeasurementKeyFrontKneeHeight:
+		return p.KneeHeight, true
+	case MeasurementKeyFrontOuterAnkleHeight:
+		return p.OuterAnkleHeight, true
+	case MeasurementKeyFrontWaistHeight:
+		return p.WaistHeight, true
+	case MeasurementKeyFrontInsideLegHeight:
+		return p.InsideLegHeight, true
+	case MeasurementKeyFrontAcrossBackShoulderWidth:
+		return p.AcrossBackShoulderWidth, true
+	case MeasurementKeyFrontAcrossBackWidth:
+		return p.AcrossBackWidth, true
+	case MeasurementKeyFrontTotalCrotchLength:
+		return p.TotalCrotchLength, true
+	case MeasurementKeyFrontWaist:
+		return p.Waist, true
+	case MeasurementKeyFrontNeckLength:
+		return p.NeckLength, true
+	case MeasurementKeyFrontUpperArmLength:
+		return p.UpperArmLength, true
+	case MeasurementKeyFrontLowerArmLength:
+		return p.LowerArmLength, true
+	case MeasurementKeyFrontUpperHipToHipLength:
+		return p.UpperHipToHipLength, true
+	case MeasurementKeyFrontBackShoulderWidth:
+		return p.BackShoulderWidth, true
+	case MeasurementKeyFrontRise:
+		return p.Rise, true
+	case MeasurementKeyFrontBackNeckToHipLength:
+		return p.BackNeckToHipLength, true
+	case MeasurementKeyFrontTorsoHeight:
+		return p.TorsoHeight, true
+	case MeasurementKeyFrontFrontTorsoHeight:
+		return p.FrontTorsoHeight, true
+	case MeasurementKeyFrontFrontCrotchLength:
+		return p.FrontCrotchLength, true
+	case MeasurementKeyFrontBackCrotchLength:
+		return p.BackCrotchLength, true
+	case MeasurementKeyFrontLegsDistance:
+		return p.LegsDistance, true
+	default:
+		return 0, false
+	}
+}
+
+func (p *SideParams) get(key MeasurementKey) (float64, bool) {
+	switch key {
+	case MeasurementKeySideBodyAreaPercentage:
+		return p.BodyAreaPercentage, true
+	case MeasurementKeySideSideUpperHipLevelToKnee:
+		return p.SideUpperHipLevelToKnee, true
+	case MeasurementKeySideSideNeckPointToUpperHip:
+		return p.SideNeckPointToUpperHip, true
+	case MeasurementKeySideNeckToChest:
+		return p.NeckToChest, true
+	case MeasurementKeySideChestToWaist:
+		return p.ChestToWaist, true
+	case MeasurementKeySideWaistToAnkle:
+		return p.WaistToAnkle, true
+	case MeasurementKeySideShouldersToKnees:
+		return p.ShouldersToKnees, true
+	default:
+		return 0, false
+	}
+}
+
+func (p *VolumeParams) get(key MeasurementKey) (float64, bool) {
+	switch key {
+	case MeasurementKeyVolumeChest:
+		return p.Chest, true
+	case MeasurementKeyVolumeUnderBustGirth:
+		return p.UnderBustGirth, true
+	case MeasurementKeyVolumeUpperChestGirth:
+		return p.UpperChestGirth, true
+	case MeasurementKeyVolumeOverarmGirth:
+		return p.OverarmGirth, true
+	case MeasurementKeyVolumeWaist:
+		return p.Waist, true
+	case MeasurementKeyVolumeAlternativeWaistGirth:
+		return p.AlternativeWaistGirth, true
+	case MeasurementKeyVolumeHighHips:
+		return p.HighHips, true
+	case MeasurementKeyVolumeLowHips:
+		return p.LowHips, true
+	case MeasurementKeyVolumeWaistGreen:
+		return p.WaistGreen, true
+	case MeasurementKeyVolumeWaistGray:
+		return p.WaistGray, true
+	case MeasurementKeyVolumePantWaist:
+		return p.PantWaist, true
+	case MeasurementKeyVolumeBicep:
+		return p.Bicep, true
+	case MeasurementKeyVolumeUpperBicepGirth:
+		return p.UpperBicepGirth, true
+	case MeasurementKeyVolumeUpperKneeGirth:
+		return p.UpperKneeGirth, true
+	case MeasurementKeyVolumeKnee:
+		return p.Knee, true
+	case MeasurementKeyVolumeAnkle:
+		return p.Ankle, true
+	case MeasurementKeyVolumeWrist:
+		return p.Wrist, true
+	case MeasurementKeyVolumeCalf:
+		return p.Calf, true
+	case MeasurementKeyVolumeThigh:
+		return p.Thigh, true
+	case MeasurementKeyVolumeThigh1InchBelowCrotch:
+		return p.Thigh1InchBelowCrotch, true
+	case MeasurementKeyVolumeMidThighGirth:
+		return p.MidThighGirth, true
+	case MeasurementKeyVolumeNeck:
+		return p.Neck, true
+	case MeasurementKeyVolumeAbdomen:
+		return p.Abdomen, true
+	case MeasurementKeyVolumeArmscyeGirth:
+		return p.ArmscyeGirth, true
+	case MeasurementKeyVolumeNeckGirth:
+		return p.NeckGirth, true
+	case MeasurementKeyVolumeNeckGirthRelaxed:
+		return p.NeckGirthRelaxed, true
+	case MeasurementKeyVolumeForearm:
+		return p.Forearm, true
+	case MeasurementKeyVolumeElbowGirth:
+		return p.ElbowGirth, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,67 @@
+package saia
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MeasurementsType is the type of measurements to be calculated.
+// Calculating only the required measurements makes the calculation lighter.
+type MeasurementsType string
+
+const (
+	// MeasurementsTypeAll calculates front, side and volume params
+	MeasurementsTypeAll MeasurementsType = "all"
+	// MeasurementsTypeFront calculates front and side params which are linear measurements
+	MeasurementsTypeFront MeasurementsType = "front"
+	// MeasurementsTypeVolume calculates volume params which are girth measurements
+	MeasurementsTypeVolume MeasurementsType = "volume"
+)
+
+// MeasurementsTypes combines the measurements types to calculate a specific subset of measurements.
+func MeasurementsTypes(types ...MeasurementsType) MeasurementsType {
+	s := make([]string, 0, len(types))
+	for _, t := range types {
+		s = append(s, string(t))
+	}
+	return MeasurementsType(strings.Join(s, ","))
+}
+
+// orDefault returns MeasurementsTypeAll when the measurements type is not specified.
+func (m MeasurementsType) orDefault() MeasurementsType {
+	if m == "" {
+		return MeasurementsTypeAll
+	}
+	return m
+}
+
+func (m MeasurementsType) includes(t MeasurementsType) bool {
+	for _, s := range strings.Split(string(m.orDefault()), ",") {
+		if MeasurementsType(s) == MeasurementsTypeAll || MeasurementsType(s) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpectsFrontParams reports whether Person.FrontParams is populated for the measurements type.
+func (m MeasurementsType) ExpectsFrontParams() bool {
+	return m.includes(MeasurementsTypeFront)
+}
+
+// ExpectsSideParams reports whether Person.SideParams is populated for the measurements type.
+func (m MeasurementsType) ExpectsSideParams() bool {
+	// measurements_type accepts only all, front and volume, there is no type for the side params.
+	// The side params are the linear measurements taken from the side photo,
+	// so they are calculated together with the front params by MeasurementsTypeFront.
+	return m.includes(MeasurementsTypeFront)
+}
+
+// ExpectsVolumeParams reports whether Person.VolumeParams is populated for the measurements type.
+func (m MeasurementsType) ExpectsVolumeParams() bool {
+	return m.includes(MeasurementsTypeVolume)
+}
+
+func (m MeasurementsType) toQueryParams() url.Values {
+	return url.Values{"measurements_type": {string(m.orDefault())}}
+}
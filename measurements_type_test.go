@@ -0,0 +1,37 @@
+package saia
+
+import "testing"
+
+func TestMeasurementsType_Expects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		measurementsType MeasurementsType
+		wantFront        bool
+		wantSide         bool
+		wantVolume       bool
+	}{
+		{name: "Unspecified", measurementsType: "", wantFront: true, wantSide: true, wantVolume: true},
+		{name: "All", measurementsType: MeasurementsTypeAll, wantFront: true, wantSide: true, wantVolume: true},
+		{name: "Front", measurementsType: MeasurementsTypeFront, wantFront: true, wantSide: true},
+		{name: "Volume", measurementsType: MeasurementsTypeVolume, wantVolume: true},
+		{name: "Subset", measurementsType: MeasurementsTypes(MeasurementsTypeFront, MeasurementsTypeVolume), wantFront: true, wantSide: true, wantVolume: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.measurementsType.ExpectsFrontParams(); got != tt.wantFront {
+				t.Errorf("ExpectsFrontParams() = %v, want %v", got, tt.wantFront)
+			}
+			if got := tt.measurementsType.ExpectsSideParams(); got != tt.wantSide {
+				t.Errorf("ExpectsSideParams() = %v, want %v", got, tt.wantSide)
+			}
+			if got := tt.measurementsType.ExpectsVolumeParams(); got != tt.wantVolume {
+				t.Errorf("ExpectsVolumeParams() = %v, want %v", got, tt.wantVolume)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package saia
+
+import "time"
+
+// MtmClient is a made-to-measure client who is measured by the widget.
+type MtmClient struct {
+	ID        int       `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Notes     string    `json:"notes"`
+	Created   time.Time `json:"created"`
+}
@@ -0,0 +1,237 @@
+package saia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type MtmClientAPI interface {
+	GetMtmClientList(ctx context.Context, options ...GetMtmClientListOption) (*GetMtmClientListResponse, error)
+	GetMtmClient(ctx context.Context, mtmClientID int) (*MtmClient, error)
+	CreateMtmClient(ctx context.Context, params *CreateMtmClientParams) (*MtmClient, error)
+	UpdateMtmClient(ctx context.Context, mtmClientID int, params *UpdateMtmClientParams) (*MtmClient, error)
+	DeleteMtmClient(ctx context.Context, mtmClientID int) error
+}
+
+type mtmClientAPI struct {
+	*apiClient
+}
+
+func newMtmClientAPI(apiClient *apiClient) *mtmClientAPI {
+	return &mtmClientAPI{apiClient}
+}
+
+type GetMtmClientListParams struct {
+	Page     int
+	PageSize int
+	// Search filters clients by name, email or phone
+	Search *string
+	// Ordering is the field to sort clients by, prefix with "-" for descending order (e.g. "-created")
+	Ordering *string
+}
+
+func newGetMtmClientListParams() *GetMtmClientListParams {
+	return &GetMtmClientListParams{
+		Page:     1,
+		PageSize: 20,
+	}
+}
+
+func (g *GetMtmClientListParams) toQueryParams() url.Values {
+	queryParams := url.Values{
+		"page":      {strconv.Itoa(g.Page)},
+		"page_size": {strconv.Itoa(g.PageSize)},
+	}
+	if g.Search != nil {
+		queryParams["search"] = []string{*g.Search}
+	}
+	if g.Ordering != nil {
+		queryParams["ordering"] = []string{*g.Ordering}
+	}
+	return queryParams
+}
+
+type GetMtmClientListOption func(*GetMtmClientListParams)
+
+func GetMtmClientListOptionPage(page int) GetMtmClientListOption {
+	return func(p *GetMtmClientListParams) {
+		p.Page = page
+	}
+}
+
+func GetMtmClientListOptionPageSize(pageSize int) GetMtmClientListOption {
+	return func(p *GetMtmClientListParams) {
+		p.PageSize = pageSize
+	}
+}
+
+func GetMtmClientListOptionSearch(search string) GetMtmClientListOption {
+	return func(p *GetMtmClientListParams) {
+		p.Search = &search
+	}
+}
+
+func GetMtmClientListOptionOrdering(ordering string) GetMtmClientListOption {
+	return func(p *GetMtmClientListParams) {
+		p.Ordering = &ordering
+	}
+}
+
+type GetMtmClientListResponse struct {
+	Count    int          `json:"count"`
+	Next     *string      `json:"next"`
+	Previous *string      `json:"previous"`
+	Results  []*MtmClient `json:"results"`
+}
+
+func (m *mtmClientAPI) GetMtmClientList(ctx context.Context, options ...GetMtmClientListOption) (*GetMtmClientListResponse, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationGetMtmClientList)
+	defer cancel()
+
+	params := newGetMtmClientListParams()
+	for _, opt := range options {
+		opt(params)
+	}
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("failed to validate params: %w", err)
+	}
+
+	url, err := m.buildURL("/measurements/mtm-clients/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	url.RawQuery = params.toQueryParams().Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var resp GetMtmClientListResponse
+	if err := m.request(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &resp, nil
+}
+
+func (m *mtmClientAPI) GetMtmClient(ctx context.Context, mtmClientID int) (*MtmClient, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationGetMtmClient)
+	defer cancel()
+
+	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-clients/%d/", mtmClientID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var mtmClient MtmClient
+	if err := m.request(req, &mtmClient); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &mtmClient, nil
+}
+
+type CreateMtmClientParams struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+func (m *mtmClientAPI) CreateMtmClient(ctx context.Context, params *CreateMtmClientParams) (*MtmClient, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationCreateMtmClient)
+	defer cancel()
+
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("failed to validate params: %w", err)
+	}
+
+	url, err := m.buildURL("/measurements/mtm-clients/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params to json: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var mtmClient MtmClient
+	if err := m.request(req, &mtmClient); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &mtmClient, nil
+}
+
+// UpdateMtmClientParams is the params to partially update a client
+// Only non-nil fields are updated.
+type UpdateMtmClientParams struct {
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+	Email     *string `json:"email,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+func (m *mtmClientAPI) UpdateMtmClient(ctx context.Context, mtmClientID int, params *UpdateMtmClientParams) (*MtmClient, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationUpdateMtmClient)
+	defer cancel()
+
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("failed to validate params: %w", err)
+	}
+
+	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-clients/%d/", mtmClientID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build url: %w", err)
+	}
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params to json: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var mtmClient MtmClient
+	if err := m.request(req, &mtmClient); err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &mtmClient, nil
+}
+
+func (m *mtmClientAPI) DeleteMtmClient(ctx context.Context, mtmClientID int) error {
+	ctx, cancel := m.withTimeout(ctx, OperationDeleteMtmClient)
+	defer cancel()
+
+	url, err := m.buildURL(fmt.Sprintf("/measurements/mtm-clients/%d/", mtmClientID))
+	if err != nil {
+		return fmt.Errorf("failed to build url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := m.request(req, nil); err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return nil
+}
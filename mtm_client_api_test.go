@@ -0,0 +1,251 @@
+package saia
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shing-dev/saia-go/pkg/convutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_mtmClientAPI_GetMtmClientList(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx     context.Context
+		options []GetMtmClientListOption
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *GetMtmClientListResponse
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{ctx: context.Background(), options: []GetMtmClientListOption{GetMtmClientListOptionSearch("john")}},
+			resp: `{
+  "count": 1,
+  "next": null,
+  "results": [{"id": 1, "first_name": "John", "last_name": "Doe", "email": "john@example.com"}]
+}`,
+			want: &GetMtmClientListResponse{
+				Count:   1,
+				Results: []*MtmClient{{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@example.com"}},
+			},
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background()},
+			resp:           `{"error": "invalid api key"}`,
+			respStatusCode: 409,
+			wantErr:        true,
+		},
+		{
+			name:    "Invalid json response",
+			args:    args{ctx: context.Background()},
+			resp:    "{",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 200
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockMtmClientAPI(t, tt.resp, statusCode)
+
+			got, err := m.GetMtmClientList(tt.args.ctx, tt.args.options...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetMtmClientList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("GetMtmClientList() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_mtmClientAPI_CreateMtmClient(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		params *CreateMtmClientParams
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *MtmClient
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{
+				ctx:    context.Background(),
+				params: &CreateMtmClientParams{FirstName: "John", LastName: "Doe", Phone: "+81123456789"},
+			},
+			resp: `{"id": 1, "first_name": "John", "last_name": "Doe", "phone": "+81123456789"}`,
+			want: &MtmClient{ID: 1, FirstName: "John", LastName: "Doe", Phone: "+81123456789"},
+		},
+		{
+			name: "Error response",
+			args: args{
+				ctx:    context.Background(),
+				params: &CreateMtmClientParams{FirstName: "John", Email: "invalid"},
+			},
+			resp:           `{"email":["Enter a valid email address."]}`,
+			respStatusCode: 400,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 201
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockMtmClientAPI(t, tt.resp, statusCode)
+
+			got, err := m.CreateMtmClient(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateMtmClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("CreateMtmClient() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_mtmClientAPI_UpdateMtmClient(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx         context.Context
+		mtmClientID int
+		params      *UpdateMtmClientParams
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *MtmClient
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{
+				ctx:         context.Background(),
+				mtmClientID: 1,
+				params:      &UpdateMtmClientParams{Notes: convutil.ToPointer("prefers slim fit")},
+			},
+			resp: `{"id": 1, "first_name": "John", "notes": "prefers slim fit"}`,
+			want: &MtmClient{ID: 1, FirstName: "John", Notes: "prefers slim fit"},
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background(), mtmClientID: 0, params: &UpdateMtmClientParams{}},
+			resp:           `{"detail": "Not found."}`,
+			respStatusCode: 404,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 200
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockMtmClientAPI(t, tt.resp, statusCode)
+
+			got, err := m.UpdateMtmClient(tt.args.ctx, tt.args.mtmClientID, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateMtmClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("UpdateMtmClient() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_mtmClientAPI_DeleteMtmClient(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx         context.Context
+		mtmClientID int
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		wantErr        bool
+	}{
+		{
+			name:           "Successful response",
+			args:           args{ctx: context.Background(), mtmClientID: 1},
+			respStatusCode: 204,
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background(), mtmClientID: 0},
+			resp:           `{"detail": "Not found."}`,
+			respStatusCode: 404,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := mockMtmClientAPI(t, tt.resp, tt.respStatusCode)
+
+			err := m.DeleteMtmClient(tt.args.ctx, tt.args.mtmClientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteMtmClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mockMtmClientAPI(t *testing.T, response string, status int) *mtmClientAPI {
+	t.Helper()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if response != "" {
+			fmt.Fprintln(w, response)
+		}
+	})
+	s := httptest.NewServer(h)
+	return &mtmClientAPI{
+		&apiClient{
+			httpClient: http.DefaultClient,
+			apiHost:    s.URL,
+		},
+	}
+}
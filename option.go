@@ -1,12 +1,33 @@
 package saia
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 type ClientOptions struct {
 	APIHost    string
 	APIKey     string
 	HttpClient *http.Client
 	Debug      bool
+	// Cache caches the persons and measurements in the terminal states, caching is disabled when it's nil
+	Cache Cache
+	// RequestCoalescingDisabled disables sharing the response of the identical GET requests in flight
+	RequestCoalescingDisabled bool
+	// CircuitBreaker is the configuration of the circuit breaker, it's disabled when nil
+	CircuitBreaker *CircuitBreakerOptions
+	// Timeout is the default timeout of the operations, it's disabled when 0
+	Timeout time.Duration
+	// OperationTimeouts overrides Timeout for each operation
+	OperationTimeouts map[Operation]time.Duration
+	// IdempotencyStore records the results of the create operations with the idempotency keys, it's in memory when nil
+	IdempotencyStore IdempotencyStore
+	// UploadMode is the transport to upload the person photos, UploadModeJSON by default
+	UploadMode UploadMode
+	// ValidationDisabled disables validating the params before sending the requests
+	ValidationDisabled bool
+	// PersonLimits is the limits of the height and weight of the person validated by the client, DefaultPersonLimits when nil
+	PersonLimits *PersonLimits
 }
 
 func newDefaultClientOptions() *ClientOptions {
@@ -14,6 +35,15 @@ func newDefaultClientOptions() *ClientOptions {
 		APIHost:    "https://saia.3dlook.me/api/v2",
 		HttpClient: http.DefaultClient,
 		Debug:      false,
+		UploadMode: UploadModeJSON,
+		Timeout:    30 * time.Second,
+		OperationTimeouts: map[Operation]time.Duration{
+			// uploading the images takes long on slow networks
+			OperationCreatePersonWithImages: 2 * time.Minute,
+			OperationPartialUpdatePerson:    2 * time.Minute,
+			// it's polled repeatedly, so a stuck request should be retried soon
+			OperationGetTaskSet: 10 * time.Second,
+		},
 	}
 }
 
@@ -56,6 +86,77 @@ func WithDebugEnabled() ClientOption {
 	})
 }
 
+// WithCache enables caching of GetPerson and GetMeasurement with the cache, e.g. NewLRUCache(1000).
+// Only the persons with the successful calculation and the finished measurements are cached.
+func WithCache(cache Cache) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.Cache = cache
+	})
+}
+
+// WithRequestCoalescingDisabled disables sharing the response of the identical GET requests in flight,
+// e.g. to measure the latency of each request.
+func WithRequestCoalescingDisabled() ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.RequestCoalescingDisabled = true
+	})
+}
+
+// WithCircuitBreaker enables the circuit breaker which fails the requests fast with ErrCircuitOpen during the outage of the API.
+func WithCircuitBreaker(opts CircuitBreakerOptions) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.CircuitBreaker = &opts
+	})
+}
+
+// WithTimeout sets the default timeout of the operations, 0 disables the timeout.
+// The timeout is applied only when the context of the operation has no earlier deadline.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.Timeout = timeout
+	})
+}
+
+// WithOperationTimeout sets the timeout of the operation, 0 disables the timeout of the operation.
+func WithOperationTimeout(op Operation, timeout time.Duration) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		timeouts := make(map[Operation]time.Duration, len(c.OperationTimeouts)+1)
+		for o, t := range c.OperationTimeouts {
+			timeouts[o] = t
+		}
+		timeouts[op] = timeout
+		c.OperationTimeouts = timeouts
+	})
+}
+
+// WithIdempotencyStore sets the store of the idempotency keys, e.g. a store backed by the database to survive restarts.
+func WithIdempotencyStore(store IdempotencyStore) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.IdempotencyStore = store
+	})
+}
+
+// WithUploadMode sets the transport to upload the person photos, e.g. UploadModeMultipart to reduce the upload size.
+func WithUploadMode(mode UploadMode) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.UploadMode = mode
+	})
+}
+
+// WithValidationDisabled disables validating the params before sending the requests, e.g. to use the limits changed by SAIA.
+func WithValidationDisabled() ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.ValidationDisabled = true
+	})
+}
+
+// WithPersonLimits sets the limits of the height and weight of the person validated before sending the requests.
+func WithPersonLimits(limits PersonLimits) ClientOption {
+	return newClientOptionFunc(func(c *ClientOptions) {
+		c.PersonLimits = &limits
+	})
+}
+
 func withAPIKey(authToken string) ClientOption {
 	return newClientOptionFunc(func(c *ClientOptions) {
 		c.APIKey = authToken
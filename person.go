@@ -1,7 +1,6 @@
 package saia
 
 import (
-	"strings"
 	"time"
 )
 
@@ -39,6 +38,61 @@ type Person struct {
 	VolumeParams *VolumeParams `json:"volume_params"`
 	IsViewed     bool          `json:"is_viewed"`
 	IsArchived   bool          `json:"is_archived"`
+	Notes        string        `json:"notes"`
+	// TaskSets is the history of calculations of the person
+	TaskSets []*PersonTaskSet `json:"task_sets"`
+}
+
+// PrimaryTaskSet returns the primary calculation of the person.
+// It falls back to the latest successful calculation when no calculation is marked as primary.
+func (p *Person) PrimaryTaskSet() *PersonTaskSet {
+	return primaryTaskSet(p.TaskSets)
+}
+
+// LatestSuccessfulTaskSet returns the latest successful calculation of the person.
+func (p *Person) LatestSuccessfulTaskSet() *PersonTaskSet {
+	return latestSuccessfulTaskSet(p.TaskSets)
+}
+
+// PersonTaskSet is a calculation of the person with its result.
+type PersonTaskSet struct {
+	ID           int           `json:"id"`
+	FrontParams  *FrontParams  `json:"front_params"`
+	SideParams   *SideParams   `json:"side_params"`
+	VolumeParams *VolumeParams `json:"volume_params"`
+	IsSuccessful bool          `json:"is_successful"`
+	IsReady      bool          `json:"is_ready"`
+	IsPrimary    bool          `json:"is_primary"`
+	SubTasks     []*SubTask    `json:"sub_tasks"`
+	Created      time.Time     `json:"created"`
+}
+
+// Err returns the errors of the failed sub tasks as *TaskError joined by errors.Join.
+// It returns nil when no sub task is failed.
+func (t *PersonTaskSet) Err() error {
+	return subTasksErr(t.SubTasks)
+}
+
+func primaryTaskSet(taskSets []*PersonTaskSet) *PersonTaskSet {
+	for _, ts := range taskSets {
+		if ts.IsPrimary {
+			return ts
+		}
+	}
+	return latestSuccessfulTaskSet(taskSets)
+}
+
+func latestSuccessfulTaskSet(taskSets []*PersonTaskSet) *PersonTaskSet {
+	var latest *PersonTaskSet
+	for _, ts := range taskSets {
+		if !ts.IsSuccessful {
+			continue
+		}
+		if latest == nil || !ts.Created.Before(latest.Created) {
+			latest = ts
+		}
+	}
+	return latest
 }
 
 type TaskSet struct {
@@ -51,6 +105,12 @@ func (t *TaskSet) IsFailed() bool {
 	return !t.IsSuccessful && t.IsReady
 }
 
+// Err returns the errors of the failed sub tasks as *TaskError joined by errors.Join.
+// It returns nil when no sub task is failed.
+func (t *TaskSet) Err() error {
+	return subTasksErr(t.SubTasks)
+}
+
 type SubTaskName string
 
 const (
@@ -64,7 +124,8 @@ const (
 type SubTaskErrorCode int
 
 // We use simple iota instead of using the application code provided by 3dlook (https://saia.3dlook.me/docs/#task-errors)
-// because they are incomplete (e.g. "Front photo in the side" is not defined)
+// because they are incomplete (e.g. "Front photo in the side" is not defined).
+// The application codes are mapped to them in task_error.go.
 const (
 	SubTaskErrorCodeUnknown SubTaskErrorCode = iota
 	SubTaskErrorCodeWrongPose
@@ -81,34 +142,18 @@ type SubTask struct {
 	Status  TaskStatus  `json:"status"`
 	TaskID  string      `json:"task_id"`
 	Message string      `json:"message"`
+	// AppCode is the application error code of the failure, it's empty when the API doesn't provide one
+	AppCode AppErrorCode `json:"code,omitempty"`
 }
 
-// ErrorCode is a workaround method to extract the error code
+// ErrorCode returns the error code of the failed sub task.
+// It prefers the application error code and falls back to matching the error message.
 // It returns unknown when the sub task is not failed
-// TODO: Ask 3dlook to return application error code which is more valid than parsing error message
 func (s *SubTask) ErrorCode() SubTaskErrorCode {
 	if !s.IsFailed() {
 		return SubTaskErrorCodeUnknown
 	}
-
-	switch {
-	case strings.HasPrefix(s.Message, "The pose is wrong"):
-		return SubTaskErrorCodeWrongPose
-	case s.Message == "Can't detect the human body" || s.Message == "We cannot find a person in the photo":
-		return SubTaskErrorCodeHumanBodyNotDetected
-	case s.Message == "The detected object is not human":
-		return SubTaskErrorCodeObjectIsNotHuman
-	case s.Message == "Side photo in the front":
-		return SubTaskErrorCodeSidePhotoInTheFront
-	case s.Message == "Front photo in the side":
-		return SubTaskErrorCodeFrontPhotoInTheSide
-	case s.Message == "The body is not full":
-		return SubTaskErrorCodeBodyIsNotFull
-	case s.Message == "Failed to determine looking side":
-		return SubTaskErrorCodeDetermineLookingSideFailed
-	default:
-		return SubTaskErrorCodeUnknown
-	}
+	return subTaskErrorCode(s.AppCode, s.Message)
 }
 
 func (s *SubTask) IsFailed() bool {
@@ -3,23 +3,37 @@ package saia
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
+	"time"
 )
 
 var uuidRegexp = regexp.MustCompile("[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}")
 
 type PersonAPI interface {
 	GetPerson(ctx context.Context, personID int) (*Person, error)
+	GetPersons(ctx context.Context, personIDs []int, options ...BatchOption) []*PersonResult
+	ListPersons(ctx context.Context, options ...ListPersonsOption) (*ListPersonsResponse, error)
 	CreatePerson(ctx context.Context, params *CreatePersonParams) (*CreatePersonResponse, error)
 	CreatePersonWithImages(ctx context.Context, params *CreatePersonWithImagesParams) (*CreatePersonWithImagesResponse, error)
-	StartCalculation(ctx context.Context, personID int) (*StartCalculationResponse, error)
+	StartCalculation(ctx context.Context, personID int, options ...StartCalculationOption) (*StartCalculationResponse, error)
 	GetTaskSet(ctx context.Context, taskSetID string) (*GetTaskSetResponse, error)
-	// TODO: Add PartialUpdatePerson method to add images after creating a person
+	ArchivePerson(ctx context.Context, personID int) (*Person, error)
+	UnarchivePerson(ctx context.Context, personID int) (*Person, error)
+	MarkPersonViewed(ctx context.Context, personID int) (*Person, error)
+	UpdatePersonNotes(ctx context.Context, personID int, notes string) (*Person, error)
+	SetPrimaryTaskSet(ctx context.Context, personID int, taskSetID int) (*Person, error)
+	PartialUpdatePerson(ctx context.Context, personID int, params *PartialUpdatePersonParams) (*Person, error)
+	DeletePerson(ctx context.Context, personID int) error
+	ArchivePersons(ctx context.Context, personIDs []int) []*BulkResult
+	UnarchivePersons(ctx context.Context, personIDs []int) []*BulkResult
+	MarkPersonsViewed(ctx context.Context, personIDs []int) []*BulkResult
+	DeletePersons(ctx context.Context, personIDs []int) []*BulkResult
 }
 
 type personAPI struct {
@@ -31,6 +45,9 @@ func newPersonAPI(apiClient *apiClient) *personAPI {
 }
 
 func (m *personAPI) GetPerson(ctx context.Context, personID int) (*Person, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationGetPerson)
+	defer cancel()
+
 	url, err := m.buildURL(fmt.Sprintf("/persons/%d/", personID))
 	if err != nil {
 		return nil, fmt.Errorf("build url: %w", err)
@@ -41,13 +58,208 @@ func (m *personAPI) GetPerson(ctx context.Context, personID int) (*Person, error
 	}
 
 	var person Person
-	if err := m.request(req, &person); err != nil {
+	if err := m.requestCached(req, personCacheKey(personID), &person, person.isTerminal); err != nil {
 		return nil, fmt.Errorf("make request: %w", err)
 	}
 
 	return &person, nil
 }
 
+type ListPersonsParams struct {
+	Page          int
+	PageSize      int
+	Gender        *Gender
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	IsArchived    *bool
+	IsViewed      *bool
+	CountryCode   *string
+	PhotoFlow     *PhotoFlowType
+	// Ordering is the field to sort persons by, prefix with "-" for descending order (e.g. "-created")
+	Ordering *string
+}
+
+func newListPersonsParams() *ListPersonsParams {
+	return &ListPersonsParams{
+		Page:     1,
+		PageSize: 20,
+	}
+}
+
+func (l *ListPersonsParams) toQueryParams() url.Values {
+	queryParams := url.Values{
+		"page":      {strconv.Itoa(l.Page)},
+		"page_size": {strconv.Itoa(l.PageSize)},
+	}
+	if l.Gender != nil {
+		queryParams["gender"] = []string{string(*l.Gender)}
+	}
+	if l.CreatedAfter != nil {
+		queryParams["created__gte"] = []string{l.CreatedAfter.Format(time.RFC3339)}
+	}
+	if l.CreatedBefore != nil {
+		queryParams["created__lt"] = []string{l.CreatedBefore.Format(time.RFC3339)}
+	}
+	if l.IsArchived != nil {
+		queryParams["is_archived"] = []string{strconv.FormatBool(*l.IsArchived)}
+	}
+	if l.IsViewed != nil {
+		queryParams["is_viewed"] = []string{strconv.FormatBool(*l.IsViewed)}
+	}
+	if l.CountryCode != nil {
+		queryParams["country_code"] = []string{*l.CountryCode}
+	}
+	if l.PhotoFlow != nil {
+		queryParams["photo_flow"] = []string{string(*l.PhotoFlow)}
+	}
+	if l.Ordering != nil {
+		queryParams["ordering"] = []string{*l.Ordering}
+	}
+	return queryParams
+}
+
+type ListPersonsOption func(*ListPersonsParams)
+
+func ListPersonsOptionPage(page int) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.Page = page
+	}
+}
+
+func ListPersonsOptionPageSize(pageSize int) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.PageSize = pageSize
+	}
+}
+
+func ListPersonsOptionGender(gender Gender) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.Gender = &gender
+	}
+}
+
+// ListPersonsOptionCreatedBetween filters persons created in [after, before)
+// Zero time is treated as unbounded.
+func ListPersonsOptionCreatedBetween(after, before time.Time) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		if !after.IsZero() {
+			p.CreatedAfter = &after
+		}
+		if !before.IsZero() {
+			p.CreatedBefore = &before
+		}
+	}
+}
+
+func ListPersonsOptionIsArchived(isArchived bool) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.IsArchived = &isArchived
+	}
+}
+
+func ListPersonsOptionIsViewed(isViewed bool) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.IsViewed = &isViewed
+	}
+}
+
+func ListPersonsOptionCountryCode(countryCode string) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.CountryCode = &countryCode
+	}
+}
+
+func ListPersonsOptionPhotoFlow(photoFlow PhotoFlowType) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.PhotoFlow = &photoFlow
+	}
+}
+
+func ListPersonsOptionOrdering(ordering string) ListPersonsOption {
+	return func(p *ListPersonsParams) {
+		p.Ordering = &ordering
+	}
+}
+
+type ListPersonsResponse struct {
+	Count    int       `json:"count"`
+	Next     *string   `json:"next"`
+	Previous *string   `json:"previous"`
+	Results  []*Person `json:"results"`
+}
+
+func (m *personAPI) ListPersons(ctx context.Context, options ...ListPersonsOption) (*ListPersonsResponse, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationListPersons)
+	defer cancel()
+
+	params := newListPersonsParams()
+	for _, opt := range options {
+		opt(params)
+	}
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	url, err := m.buildURL("/persons/")
+	if err != nil {
+		return nil, fmt.Errorf("build url: %w", err)
+	}
+	url.RawQuery = params.toQueryParams().Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	var resp ListPersonsResponse
+	if err := m.request(req, &resp); err != nil {
+		return nil, fmt.Errorf("make request: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// PersonIterator iterates over the persons fetching them page by page.
+type PersonIterator struct {
+	ctx       context.Context
+	personAPI PersonAPI
+	options   []ListPersonsOption
+
+	page    int
+	persons []*Person
+	done    bool
+}
+
+// NewPersonIterator returns an iterator over the persons matching the options.
+// The page option is ignored since pages are fetched automatically.
+func NewPersonIterator(ctx context.Context, personAPI PersonAPI, options ...ListPersonsOption) *PersonIterator {
+	return &PersonIterator{
+		ctx:       ctx,
+		personAPI: personAPI,
+		options:   options,
+		page:      1,
+	}
+}
+
+// Next returns the next person, or io.EOF when there are no more persons.
+func (it *PersonIterator) Next() (*Person, error) {
+	for len(it.persons) == 0 {
+		if it.done {
+			return nil, io.EOF
+		}
+		options := append(append([]ListPersonsOption{}, it.options...), ListPersonsOptionPage(it.page))
+		resp, err := it.personAPI.ListPersons(it.ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		it.persons = resp.Results
+		it.done = resp.Next == nil || len(resp.Results) == 0
+		it.page++
+	}
+	person := it.persons[0]
+	it.persons = it.persons[1:]
+	return person, nil
+}
+
 type PhotoFlowType string
 
 const (
@@ -73,6 +285,11 @@ type CreatePersonParams struct {
 	Height int `json:"height"`
 	// Weight of person, in kg
 	Weight float64 `json:"weight"`
+	// MeasurementsType is the type of measurements to be calculated, all by default
+	MeasurementsType MeasurementsType `json:"-"`
+	// IdempotencyKey makes retries with the same key return the earlier result instead of creating another person,
+	// e.g. NewIdempotencyKey()
+	IdempotencyKey string `json:"-"`
 }
 
 type CreatePersonResponse struct {
@@ -81,13 +298,23 @@ type CreatePersonResponse struct {
 	Gender Gender  `json:"gender"`
 	Height int     `json:"height"`
 	Weight float64 `json:"weight"`
+	// MeasurementsType is the type of measurements which will be calculated
+	MeasurementsType MeasurementsType `json:"-"`
 }
 
 func (m *personAPI) CreatePerson(ctx context.Context, params *CreatePersonParams) (*CreatePersonResponse, error) {
-	url, err := m.buildURL("/persons/?measurements_type=all")
+	ctx, cancel := m.withTimeout(ctx, OperationCreatePerson)
+	defer cancel()
+
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	url, err := m.buildURL("/persons/")
 	if err != nil {
 		return nil, fmt.Errorf("build url: %w", err)
 	}
+	url.RawQuery = params.MeasurementsType.toQueryParams().Encode()
 	reqBody, err := json.Marshal(params)
 	if err != nil {
 		return nil, fmt.Errorf("marshal params to json: %w", err)
@@ -98,9 +325,10 @@ func (m *personAPI) CreatePerson(ctx context.Context, params *CreatePersonParams
 	}
 
 	var resp CreatePersonResponse
-	if err := m.request(req, &resp); err != nil {
+	if err := m.requestIdempotent(req, OperationCreatePerson, params.IdempotencyKey, &resp); err != nil {
 		return nil, fmt.Errorf("make request: %w", err)
 	}
+	resp.MeasurementsType = params.MeasurementsType.orDefault()
 
 	return &resp, nil
 }
@@ -118,69 +346,111 @@ type CreatePersonWithImagesParams struct {
 	SideImage         io.Reader
 	DeviceCoordinates *DeviceCoordinates
 	PhotoFlowType     PhotoFlowType
+	// MeasurementsType is the type of measurements to be calculated, all by default
+	MeasurementsType MeasurementsType
+	// IdempotencyKey makes retries with the same key return the earlier task set instead of resubmitting the images,
+	// e.g. NewIdempotencyKey()
+	IdempotencyKey string
+	// OnUploadProgress is called with the bytes of the body written to the network while uploading,
+	// total is -1 until the end of the upload when the length is unknown, e.g. UploadModeMultipart.
+	// It's called from the goroutine of the HTTP transport, not the caller's one, so synchronize the state it updates
+	OnUploadProgress func(sent, total int64)
 }
 
-func (c *CreatePersonWithImagesParams) toJSON() ([]byte, error) {
-	frontImageBytes, err := io.ReadAll(c.FrontImage)
-	if err != nil {
-		return nil, fmt.Errorf("read front image: %w", err)
-	}
-	sideImageBytes, err := io.ReadAll(c.SideImage)
-	if err != nil {
-		return nil, fmt.Errorf("read side image: %w", err)
+func (c *CreatePersonWithImagesParams) formParts() []*formPart {
+	return []*formPart{
+		{name: "gender", value: c.Gender},
+		{name: "height", value: c.Height},
+		{name: "weight", value: c.Weight},
+		{name: "front_image", image: c.FrontImage},
+		{name: "side_image", image: c.SideImage},
+		{name: "phone_position", value: c.DeviceCoordinates},
+		{name: "photo_flow", value: c.PhotoFlowType},
 	}
-
-	return json.Marshal(map[string]any{
-		"gender":         c.Gender,
-		"height":         c.Height,
-		"weight":         c.Weight,
-		"front_image":    base64.StdEncoding.EncodeToString(frontImageBytes),
-		"side_image":     base64.StdEncoding.EncodeToString(sideImageBytes),
-		"phone_position": c.DeviceCoordinates,
-		"photo_flow":     c.PhotoFlowType,
-	})
 }
 
 type CreatePersonWithImagesResponse struct {
 	TaskSetURL string `json:"task_set_url"`
 	TaskSetID  string `json:"-"`
+	// MeasurementsType is the type of measurements which will be calculated
+	MeasurementsType MeasurementsType `json:"-"`
 }
 
 func (m *personAPI) CreatePersonWithImages(ctx context.Context, params *CreatePersonWithImagesParams) (*CreatePersonWithImagesResponse, error) {
-	url, err := m.buildURL("/persons/?measurements_type=all")
+	ctx, cancel := m.withTimeout(ctx, OperationCreatePersonWithImages)
+	defer cancel()
+
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	url, err := m.buildURL("/persons/")
 	if err != nil {
 		return nil, fmt.Errorf("build url: %w", err)
 	}
-	reqBody, err := params.toJSON()
+	url.RawQuery = params.MeasurementsType.toQueryParams().Encode()
+	reqBody, contentType, err := encodeForm(m.uploadMode, params.formParts())
 	if err != nil {
-		return nil, fmt.Errorf("convert params to json: %w", err)
+		return nil, fmt.Errorf("encode params: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewReader(reqBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), reqBody)
 	if err != nil {
+		closeReader(reqBody)
 		return nil, fmt.Errorf("create request: %w", err)
 	}
+	req.Header.Set("Content-Type", contentType)
+	withUploadProgress(req, params.OnUploadProgress)
 
 	var resp CreatePersonWithImagesResponse
-	if err := m.request(req, &resp); err != nil {
+	if err := m.requestIdempotent(req, OperationCreatePersonWithImages, params.IdempotencyKey, &resp); err != nil {
 		return nil, fmt.Errorf("make request: %w", err)
 	}
 
 	taskSetID := uuidRegexp.FindStringSubmatch(resp.TaskSetURL)[0]
 	resp.TaskSetID = taskSetID
+	resp.MeasurementsType = params.MeasurementsType.orDefault()
 
 	return &resp, nil
 }
 
+type StartCalculationParams struct {
+	// MeasurementsType is the type of measurements to be calculated, all by default
+	MeasurementsType MeasurementsType
+}
+
+type StartCalculationOption func(*StartCalculationParams)
+
+func StartCalculationOptionMeasurementsType(measurementsType MeasurementsType) StartCalculationOption {
+	return func(p *StartCalculationParams) {
+		p.MeasurementsType = measurementsType
+	}
+}
+
 type StartCalculationResponse struct {
 	TaskSetURL string `json:"task_set_url"`
 	TaskSetID  string `json:"-"`
+	// MeasurementsType is the type of measurements which will be calculated
+	MeasurementsType MeasurementsType `json:"-"`
 }
 
-func (m *personAPI) StartCalculation(ctx context.Context, personID int) (*StartCalculationResponse, error) {
-	url, err := m.buildURL(fmt.Sprintf("/persons/%d/calculate/?measurements_type=all", personID))
+func (m *personAPI) StartCalculation(ctx context.Context, personID int, options ...StartCalculationOption) (*StartCalculationResponse, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationStartCalculation)
+	defer cancel()
+	defer m.invalidate(personCacheKey(personID))
+
+	params := &StartCalculationParams{}
+	for _, opt := range options {
+		opt(params)
+	}
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	url, err := m.buildURL(fmt.Sprintf("/persons/%d/calculate/", personID))
 	if err != nil {
 		return nil, fmt.Errorf("build url: %w", err)
 	}
+	url.RawQuery = params.MeasurementsType.toQueryParams().Encode()
 	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
@@ -193,6 +463,7 @@ func (m *personAPI) StartCalculation(ctx context.Context, personID int) (*StartC
 
 	taskSetID := uuidRegexp.FindStringSubmatch(resp.TaskSetURL)[0]
 	resp.TaskSetID = taskSetID
+	resp.MeasurementsType = params.MeasurementsType.orDefault()
 
 	return &resp, nil
 }
@@ -206,6 +477,9 @@ type GetTaskSetResponse struct {
 }
 
 func (m *personAPI) GetTaskSet(ctx context.Context, taskSetID string) (*GetTaskSetResponse, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationGetTaskSet)
+	defer cancel()
+
 	url, err := m.buildURL(fmt.Sprintf("/queue/%s/", taskSetID))
 	if err != nil {
 		return nil, fmt.Errorf("build url: %w", err)
@@ -215,21 +489,18 @@ func (m *personAPI) GetTaskSet(ctx context.Context, taskSetID string) (*GetTaskS
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
-	resp, err := m.do(req)
+	resp, err := m.doCoalesced(req)
 	if err != nil {
 		return nil, fmt.Errorf("make request: %w", err)
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode >= 500 {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err == nil {
-			return nil, fmt.Errorf("failed to send request status: %s, body: %s", resp.Status, string(bodyBytes))
-		}
-		return nil, fmt.Errorf("failed to send request: %s", resp.Status)
+		return nil, newAPIError(resp)
 	}
 
 	respBody := map[string]interface{}{}
 	if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+		return nil, fmt.Errorf("failed to decode response body: %w", timeoutErr(ctx, err))
 	}
 	respJSON, err := json.Marshal(respBody)
 	if err != nil {
@@ -250,3 +521,208 @@ func (m *personAPI) GetTaskSet(ctx context.Context, taskSetID string) (*GetTaskS
 		return &GetTaskSetResponse{Person: &person}, nil
 	}
 }
+
+func (m *personAPI) ArchivePerson(ctx context.Context, personID int) (*Person, error) {
+	return m.partialUpdatePerson(ctx, personID, map[string]any{"is_archived": true})
+}
+
+func (m *personAPI) UnarchivePerson(ctx context.Context, personID int) (*Person, error) {
+	return m.partialUpdatePerson(ctx, personID, map[string]any{"is_archived": false})
+}
+
+func (m *personAPI) MarkPersonViewed(ctx context.Context, personID int) (*Person, error) {
+	return m.partialUpdatePerson(ctx, personID, map[string]any{"is_viewed": true})
+}
+
+func (m *personAPI) UpdatePersonNotes(ctx context.Context, personID int, notes string) (*Person, error) {
+	return m.partialUpdatePerson(ctx, personID, map[string]any{"notes": notes})
+}
+
+// SetPrimaryTaskSet marks the calculation as primary, e.g. to use the result of a recalculation.
+func (m *personAPI) SetPrimaryTaskSet(ctx context.Context, personID int, taskSetID int) (*Person, error) {
+	return m.partialUpdatePerson(ctx, personID, map[string]any{"primary_task_set": taskSetID})
+}
+
+// PartialUpdatePersonParams is the params to add or replace the photos of a person after creating it.
+// Only the non-zero fields are updated.
+type PartialUpdatePersonParams struct {
+	// FrontImage is front image file
+	FrontImage io.Reader
+	// SideImage is side image file
+	SideImage         io.Reader
+	DeviceCoordinates *DeviceCoordinates
+	PhotoFlowType     PhotoFlowType
+	// OnUploadProgress is called with the bytes of the body written to the network while uploading,
+	// total is -1 until the end of the upload when the length is unknown, e.g. UploadModeMultipart.
+	// It's called from the goroutine of the HTTP transport, not the caller's one, so synchronize the state it updates
+	OnUploadProgress func(sent, total int64)
+}
+
+func (p *PartialUpdatePersonParams) formParts() []*formPart {
+	var parts []*formPart
+	if p.FrontImage != nil {
+		parts = append(parts, &formPart{name: "front_image", image: p.FrontImage})
+	}
+	if p.SideImage != nil {
+		parts = append(parts, &formPart{name: "side_image", image: p.SideImage})
+	}
+	if p.DeviceCoordinates != nil {
+		parts = append(parts, &formPart{name: "phone_position", value: p.DeviceCoordinates})
+	}
+	if p.PhotoFlowType != "" {
+		parts = append(parts, &formPart{name: "photo_flow", value: p.PhotoFlowType})
+	}
+	return parts
+}
+
+// PartialUpdatePerson adds or replaces the photos of the person, call StartCalculation to calculate with them.
+func (m *personAPI) PartialUpdatePerson(ctx context.Context, personID int, params *PartialUpdatePersonParams) (*Person, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationPartialUpdatePerson)
+	defer cancel()
+	defer m.invalidate(personCacheKey(personID))
+
+	if err := m.validate(params); err != nil {
+		return nil, fmt.Errorf("validate params: %w", err)
+	}
+
+	url, err := m.buildURL(fmt.Sprintf("/persons/%d/", personID))
+	if err != nil {
+		return nil, fmt.Errorf("build url: %w", err)
+	}
+	reqBody, contentType, err := encodeForm(m.uploadMode, params.formParts())
+	if err != nil {
+		return nil, fmt.Errorf("encode params: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url.String(), reqBody)
+	if err != nil {
+		closeReader(reqBody)
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	withUploadProgress(req, params.OnUploadProgress)
+
+	var person Person
+	if err := m.request(req, &person); err != nil {
+		return nil, fmt.Errorf("make request: %w", err)
+	}
+
+	return &person, nil
+}
+
+func (m *personAPI) partialUpdatePerson(ctx context.Context, personID int, fields map[string]any) (*Person, error) {
+	ctx, cancel := m.withTimeout(ctx, OperationUpdatePerson)
+	defer cancel()
+	defer m.invalidate(personCacheKey(personID))
+
+	url, err := m.buildURL(fmt.Sprintf("/persons/%d/", personID))
+	if err != nil {
+		return nil, fmt.Errorf("build url: %w", err)
+	}
+	reqBody, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("marshal params to json: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	var person Person
+	if err := m.request(req, &person); err != nil {
+		return nil, fmt.Errorf("make request: %w", err)
+	}
+
+	return &person, nil
+}
+
+func (m *personAPI) DeletePerson(ctx context.Context, personID int) error {
+	ctx, cancel := m.withTimeout(ctx, OperationDeletePerson)
+	defer cancel()
+	defer m.invalidate(personCacheKey(personID))
+
+	url, err := m.buildURL(fmt.Sprintf("/persons/%d/", personID))
+	if err != nil {
+		return fmt.Errorf("build url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	if err := m.request(req, nil); err != nil {
+		return fmt.Errorf("make request: %w", err)
+	}
+
+	return nil
+}
+
+func (m *personAPI) ArchivePersons(ctx context.Context, personIDs []int) []*BulkResult {
+	return bulk(ctx, personIDs, func(ctx context.Context, id int) error {
+		_, err := m.ArchivePerson(ctx, id)
+		return err
+	})
+}
+
+func (m *personAPI) UnarchivePersons(ctx context.Context, personIDs []int) []*BulkResult {
+	return bulk(ctx, personIDs, func(ctx context.Context, id int) error {
+		_, err := m.UnarchivePerson(ctx, id)
+		return err
+	})
+}
+
+func (m *personAPI) MarkPersonsViewed(ctx context.Context, personIDs []int) []*BulkResult {
+	return bulk(ctx, personIDs, func(ctx context.Context, id int) error {
+		_, err := m.MarkPersonViewed(ctx, id)
+		return err
+	})
+}
+
+func (m *personAPI) DeletePersons(ctx context.Context, personIDs []int) []*BulkResult {
+	return bulk(ctx, personIDs, m.DeletePerson)
+}
+
+// PersonResult is the result of GetPersons for each person.
+type PersonResult struct {
+	ID     int
+	Person *Person
+	Err    error
+}
+
+// GetPersons gets the persons concurrently and returns the results in the order of personIDs.
+// The failure of a person (e.g. not found) is reported in its result and doesn't stop the others.
+func (m *personAPI) GetPersons(ctx context.Context, personIDs []int, options ...BatchOption) []*PersonResult {
+	persons, errs := batch(ctx, personIDs, options, m.GetPerson)
+	results := make([]*PersonResult, len(personIDs))
+	for i, id := range personIDs {
+		results[i] = &PersonResult{ID: id, Person: persons[i], Err: errs[i]}
+	}
+	return results
+}
+
+// WaitForTaskSet polls the task set until it is finished and returns the last response.
+// It returns the measured person when the task set is successful.
+// When the task set is failed, it returns the response with the failed task set and its error,
+// use errors.As to get the *TaskError, the error is ErrTaskSetFailed when no sub task reports the failure.
+func WaitForTaskSet(ctx context.Context, personAPI PersonAPI, taskSetID string, interval time.Duration) (*GetTaskSetResponse, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := personAPI.GetTaskSet(ctx, taskSetID)
+		if err != nil {
+			return nil, fmt.Errorf("get task set: %w", err)
+		}
+		if resp.Person != nil {
+			return resp, nil
+		}
+		if resp.TaskSet.IsReady {
+			return resp, TaskSetErr(resp.TaskSet)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
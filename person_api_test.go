@@ -3,11 +3,19 @@ package saia
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/go-cmp/cmp"
+	"github.com/shing-dev/saia-go/pkg/convutil"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func Test_personAPI_GetPerson(t *testing.T) {
@@ -73,6 +81,168 @@ func Test_personAPI_GetPerson(t *testing.T) {
 	}
 }
 
+func Test_personAPI_GetPersons(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.Path == "/persons/2/" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"detail": "Not found."}`)
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/persons/"), "/")
+		fmt.Fprintf(w, `{"id": %s}`, id)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+	ids := []int{5, 2, 3, 1, 4, 6}
+	got := m.GetPersons(context.Background(), ids, BatchOptionConcurrency(2))
+
+	var gotIDs []int
+	for i, r := range got {
+		gotIDs = append(gotIDs, r.ID)
+		if r.ID == 2 {
+			if r.Err == nil || r.Person != nil {
+				t.Errorf("GetPersons()[%d] = %+v, want error", i, r)
+			}
+			continue
+		}
+		if r.Err != nil || r.Person.ID != r.ID {
+			t.Errorf("GetPersons()[%d] = %+v, want person %d", i, r, r.ID)
+		}
+	}
+	if diff := cmp.Diff(gotIDs, ids); diff != "" {
+		t.Errorf("GetPersons() ids (-got, +want)\n%s", diff)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("GetPersons() sent %d requests concurrently, want at most 2", maxInFlight)
+	}
+}
+
+func Test_personAPI_ListPersons(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx     context.Context
+		options []ListPersonsOption
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *ListPersonsResponse
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{
+				ctx:     context.Background(),
+				options: []ListPersonsOption{ListPersonsOptionGender(GenderMale), ListPersonsOptionIsArchived(false)},
+			},
+			resp: `{
+  "count": 21,
+  "next": "https://saia.3dlook.me/api/v2/persons/?page=2&page_size=20",
+  "results": [{"id": 1021366, "gender": "male"}]
+}`,
+			want: &ListPersonsResponse{
+				Count:   21,
+				Next:    convutil.ToPointer("https://saia.3dlook.me/api/v2/persons/?page=2&page_size=20"),
+				Results: []*Person{{ID: 1021366, Gender: GenderMale}},
+			},
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background()},
+			resp:           `{"error": "invalid api key"}`,
+			respStatusCode: 409,
+			wantErr:        true,
+		},
+		{
+			name:    "Invalid json response",
+			args:    args{ctx: context.Background()},
+			resp:    "{",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 200
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockPersonAPI(t, tt.resp, statusCode)
+
+			got, err := m.ListPersons(tt.args.ctx, tt.args.options...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListPersons() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("ListPersons() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPersonIterator(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		"1": `{"count": 3, "next": "next", "results": [{"id": 1}, {"id": 2}]}`,
+		"2": `{"count": 3, "next": null, "results": [{"id": 3}]}`,
+	}
+	var gotQueries []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQueries = append(gotQueries, r.URL.RawQuery)
+		fmt.Fprintln(w, pages[r.URL.Query().Get("page")])
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+	created := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	it := NewPersonIterator(context.Background(), m, ListPersonsOptionCreatedBetween(created, time.Time{}), ListPersonsOptionPageSize(2))
+	var gotIDs []int
+	for {
+		person, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		gotIDs = append(gotIDs, person.ID)
+	}
+
+	if diff := cmp.Diff(gotIDs, []int{1, 2, 3}); diff != "" {
+		t.Errorf("Next() ids (-got, +want)\n%s", diff)
+	}
+	wantQueries := []string{
+		"created__gte=2023-04-01T00%3A00%3A00Z&page=1&page_size=2",
+		"created__gte=2023-04-01T00%3A00%3A00Z&page=2&page_size=2",
+	}
+	if diff := cmp.Diff(gotQueries, wantQueries); diff != "" {
+		t.Errorf("Next() queries (-got, +want)\n%s", diff)
+	}
+}
+
 func Test_personAPI_CreatePerson(t *testing.T) {
 	t.Parallel()
 
@@ -85,8 +255,12 @@ func Test_personAPI_CreatePerson(t *testing.T) {
 		args           args
 		resp           string
 		respStatusCode int
-		want           *CreatePersonResponse
-		wantErr        bool
+		// validationDisabled sends the invalid params to the API
+		validationDisabled bool
+		want               *CreatePersonResponse
+		wantErr            bool
+		// wantValidationErr is true when the params are rejected before sending the request
+		wantValidationErr bool
 	}{
 		{
 			name: "Successful response",
@@ -107,11 +281,12 @@ func Test_personAPI_CreatePerson(t *testing.T) {
     "weight": 70.1
 }`,
 			want: &CreatePersonResponse{
-				ID:     3,
-				URL:    "https://saia.3dlook.me/api/v2/persons/3/?measurements_type=all",
-				Gender: GenderFemale,
-				Height: 170,
-				Weight: 70.1,
+				ID:               3,
+				URL:              "https://saia.3dlook.me/api/v2/persons/3/?measurements_type=all",
+				Gender:           GenderFemale,
+				Height:           170,
+				Weight:           70.1,
+				MeasurementsType: MeasurementsTypeAll,
 			},
 		},
 		{
@@ -124,9 +299,23 @@ func Test_personAPI_CreatePerson(t *testing.T) {
 					Weight: 70.1,
 				},
 			},
-			resp:           `{"height":["This field must be an number between 150 and 230."]}`,
-			respStatusCode: 400,
-			wantErr:        true,
+			resp:               `{"height":["This field must be an number between 150 and 230."]}`,
+			respStatusCode:     400,
+			validationDisabled: true,
+			wantErr:            true,
+		},
+		{
+			name: "Validation error",
+			args: args{
+				ctx: context.Background(),
+				params: &CreatePersonParams{
+					Gender: GenderFemale,
+					Height: 120,
+					Weight: 70.1,
+				},
+			},
+			wantErr:           true,
+			wantValidationErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -139,12 +328,17 @@ func Test_personAPI_CreatePerson(t *testing.T) {
 				statusCode = tt.respStatusCode
 			}
 			m := mockPersonAPI(t, tt.resp, statusCode)
+			m.validationDisabled = tt.validationDisabled
 
 			got, err := m.CreatePerson(tt.args.ctx, tt.args.params)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreatePerson() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) != tt.wantValidationErr {
+				t.Errorf("CreatePerson() error = %v, wantValidationErr %v", err, tt.wantValidationErr)
+			}
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Errorf("CreatePerson() (-got, +want)\n%s", diff)
 			}
@@ -164,27 +358,33 @@ func Test_personAPI_CreatePersonWithImages(t *testing.T) {
 		args           args
 		resp           string
 		respStatusCode int
-		want           *CreatePersonWithImagesResponse
-		wantErr        bool
+		// validationDisabled sends the invalid params to the API
+		validationDisabled bool
+		want               *CreatePersonWithImagesResponse
+		wantErr            bool
+		// wantValidationErr is true when the params are rejected before sending the request
+		wantValidationErr bool
 	}{
 		{
 			name: "Successful response",
 			args: args{
 				ctx: context.Background(),
 				params: &CreatePersonWithImagesParams{
-					Gender:     GenderFemale,
-					Height:     170,
-					Weight:     70.1,
-					FrontImage: bytes.NewReader([]byte("dummy front image")),
-					SideImage:  bytes.NewReader([]byte("dummy side image")),
+					Gender:        GenderFemale,
+					Height:        170,
+					Weight:        70.1,
+					FrontImage:    bytes.NewReader([]byte("dummy front image")),
+					SideImage:     bytes.NewReader([]byte("dummy side image")),
+					PhotoFlowType: PhotoFlowTypeFriend,
 				},
 			},
 			resp: `{
 	"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/"
 }`,
 			want: &CreatePersonWithImagesResponse{
-				TaskSetURL: "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/",
-				TaskSetID:  "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+				TaskSetURL:       "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/",
+				TaskSetID:        "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+				MeasurementsType: MeasurementsTypeAll,
 			},
 		},
 		{
@@ -192,16 +392,34 @@ func Test_personAPI_CreatePersonWithImages(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				params: &CreatePersonWithImagesParams{
-					Gender:     GenderFemale,
-					Height:     120,
-					Weight:     70.1,
-					FrontImage: bytes.NewReader([]byte("dummy front image")),
-					SideImage:  bytes.NewReader([]byte("dummy side image")),
+					Gender:        GenderFemale,
+					Height:        120,
+					Weight:        70.1,
+					FrontImage:    bytes.NewReader([]byte("dummy front image")),
+					SideImage:     bytes.NewReader([]byte("dummy side image")),
+					PhotoFlowType: PhotoFlowTypeFriend,
 				},
 			},
-			resp:           `{"height":["This field must be an number between 150 and 230."]}`,
-			respStatusCode: 400,
-			wantErr:        true,
+			resp:               `{"height":["This field must be an number between 150 and 230."]}`,
+			respStatusCode:     400,
+			validationDisabled: true,
+			wantErr:            true,
+		},
+		{
+			name: "Validation error",
+			args: args{
+				ctx: context.Background(),
+				params: &CreatePersonWithImagesParams{
+					Gender:        GenderFemale,
+					Height:        120,
+					Weight:        70.1,
+					FrontImage:    bytes.NewReader([]byte("dummy front image")),
+					SideImage:     bytes.NewReader([]byte("dummy side image")),
+					PhotoFlowType: PhotoFlowTypeFriend,
+				},
+			},
+			wantErr:           true,
+			wantValidationErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -214,12 +432,17 @@ func Test_personAPI_CreatePersonWithImages(t *testing.T) {
 				statusCode = tt.respStatusCode
 			}
 			m := mockPersonAPI(t, tt.resp, statusCode)
+			m.validationDisabled = tt.validationDisabled
 
 			got, err := m.CreatePersonWithImages(tt.args.ctx, tt.args.params)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreatePerson() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) != tt.wantValidationErr {
+				t.Errorf("CreatePerson() error = %v, wantValidationErr %v", err, tt.wantValidationErr)
+			}
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Errorf("CreatePerson() (-got, +want)\n%s", diff)
 			}
@@ -250,8 +473,9 @@ func Test_personAPI_StartCalculation(t *testing.T) {
 	"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/"
 }`,
 			want: &StartCalculationResponse{
-				TaskSetURL: "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/",
-				TaskSetID:  "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+				TaskSetURL:       "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/",
+				TaskSetID:        "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+				MeasurementsType: MeasurementsTypeAll,
 			},
 		},
 		{
@@ -291,6 +515,321 @@ func Test_personAPI_StartCalculation(t *testing.T) {
 	}
 }
 
+func Test_personAPI_UpdatePersonNotes(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx      context.Context
+		personID int
+		notes    string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		resp           string
+		respStatusCode int
+		want           *Person
+		wantErr        bool
+	}{
+		{
+			name: "Successful response",
+			args: args{ctx: context.Background(), personID: 1021366, notes: "re-scan requested"},
+			resp: `{"id": 1021366, "notes": "re-scan requested"}`,
+			want: &Person{ID: 1021366, Notes: "re-scan requested"},
+		},
+		{
+			name:           "Error response",
+			args:           args{ctx: context.Background(), personID: 0000},
+			resp:           `{"detail": "Not found."}`,
+			respStatusCode: 404,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCode := 200
+			if tt.respStatusCode > 0 {
+				statusCode = tt.respStatusCode
+			}
+			m := mockPersonAPI(t, tt.resp, statusCode)
+
+			got, err := m.UpdatePersonNotes(tt.args.ctx, tt.args.personID, tt.args.notes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdatePersonNotes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("UpdatePersonNotes() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_personAPI_UpdatePerson(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		call       func(m PersonAPI) error
+		wantMethod string
+		wantBody   string
+	}{
+		{
+			name: "ArchivePerson",
+			call: func(m PersonAPI) error {
+				_, err := m.ArchivePerson(context.Background(), 1)
+				return err
+			},
+			wantMethod: "PATCH",
+			wantBody:   `{"is_archived":true}`,
+		},
+		{
+			name: "UnarchivePerson",
+			call: func(m PersonAPI) error {
+				_, err := m.UnarchivePerson(context.Background(), 1)
+				return err
+			},
+			wantMethod: "PATCH",
+			wantBody:   `{"is_archived":false}`,
+		},
+		{
+			name: "MarkPersonViewed",
+			call: func(m PersonAPI) error {
+				_, err := m.MarkPersonViewed(context.Background(), 1)
+				return err
+			},
+			wantMethod: "PATCH",
+			wantBody:   `{"is_viewed":true}`,
+		},
+		{
+			name: "DeletePerson",
+			call: func(m PersonAPI) error {
+				return m.DeletePerson(context.Background(), 1)
+			},
+			wantMethod: "DELETE",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotMethod, gotPath, gotBody string
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(body)
+				fmt.Fprintln(w, `{"id": 1}`)
+			})
+			s := httptest.NewServer(h)
+			defer s.Close()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+			if err := tt.call(m); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if gotMethod != tt.wantMethod || gotPath != "/persons/1/" || gotBody != tt.wantBody {
+				t.Errorf("%s() sent %s %s %s, want %s /persons/1/ %s", tt.name, gotMethod, gotPath, gotBody, tt.wantMethod, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_personAPI_SetPrimaryTaskSet(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotPath, gotBody string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(body)
+		fmt.Fprintln(w, `{"id": 1}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	cache := NewLRUCache(10)
+	cache.Set(personCacheKey(1), &CacheEntry{Body: []byte(`{"id": 1}`)})
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, cache: cache}}
+
+	got, err := m.SetPrimaryTaskSet(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("SetPrimaryTaskSet() error = %v", err)
+	}
+	if diff := cmp.Diff(got, &Person{ID: 1}); diff != "" {
+		t.Errorf("SetPrimaryTaskSet() (-got, +want)\n%s", diff)
+	}
+	if gotMethod != "PATCH" || gotPath != "/persons/1/" || gotBody != `{"primary_task_set":42}` {
+		t.Errorf("SetPrimaryTaskSet() sent %s %s %s", gotMethod, gotPath, gotBody)
+	}
+	if _, ok := cache.Get(personCacheKey(1)); ok {
+		t.Errorf("SetPrimaryTaskSet() didn't invalidate the cached person")
+	}
+}
+
+func Test_personAPI_BulkUpdatePersons(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		call       func(m PersonAPI, ctx context.Context, personIDs []int) []*BulkResult
+		wantMethod string
+		wantBody   string
+	}{
+		{name: "ArchivePersons", call: PersonAPI.ArchivePersons, wantMethod: "PATCH", wantBody: `{"is_archived":true}`},
+		{name: "UnarchivePersons", call: PersonAPI.UnarchivePersons, wantMethod: "PATCH", wantBody: `{"is_archived":false}`},
+		{name: "MarkPersonsViewed", call: PersonAPI.MarkPersonsViewed, wantMethod: "PATCH", wantBody: `{"is_viewed":true}`},
+		{name: "DeletePersons", call: PersonAPI.DeletePersons, wantMethod: "DELETE"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu       sync.Mutex
+				requests []string
+			)
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+				mu.Unlock()
+				if r.URL.Path == "/persons/2/" {
+					w.WriteHeader(404)
+					fmt.Fprintln(w, `{"detail": "Not found."}`)
+					return
+				}
+				fmt.Fprintln(w, `{"id": 1}`)
+			})
+			s := httptest.NewServer(h)
+			defer s.Close()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+			results := tt.call(m, context.Background(), []int{1, 2, 3})
+			if len(results) != 3 {
+				t.Fatalf("%s() returned %d results, want 3", tt.name, len(results))
+			}
+			for i, wantErr := range []bool{false, true, false} {
+				if results[i].ID != i+1 || (results[i].Err != nil) != wantErr {
+					t.Errorf("%s() results[%d] = {%d, %v}, wantErr %v", tt.name, i, results[i].ID, results[i].Err, wantErr)
+				}
+			}
+			var want []string
+			for _, id := range []int{1, 2, 3} {
+				want = append(want, fmt.Sprintf("%s /persons/%d/ %s", tt.wantMethod, id, tt.wantBody))
+			}
+			sort.Strings(requests)
+			if diff := cmp.Diff(requests, want); diff != "" {
+				t.Errorf("%s() requests (-got, +want)\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func Test_personAPI_StartCalculation_MeasurementsType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options []StartCalculationOption
+		want    string
+	}{
+		{name: "Default", want: "measurements_type=all"},
+		{
+			name:    "Volume",
+			options: []StartCalculationOption{StartCalculationOptionMeasurementsType(MeasurementsTypeVolume)},
+			want:    "measurements_type=volume",
+		},
+		{
+			name:    "Subset",
+			options: []StartCalculationOption{StartCalculationOptionMeasurementsType(MeasurementsTypes(MeasurementsTypeFront, MeasurementsTypeVolume))},
+			want:    "measurements_type=front%2Cvolume",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotQuery string
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotQuery = r.URL.RawQuery
+				fmt.Fprintln(w, `{"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/"}`)
+			})
+			s := httptest.NewServer(h)
+			defer s.Close()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL}}
+
+			if _, err := m.StartCalculation(context.Background(), 1, tt.options...); err != nil {
+				t.Fatalf("StartCalculation() error = %v", err)
+			}
+			if gotQuery != tt.want {
+				t.Errorf("StartCalculation() query = %s, want %s", gotQuery, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForTaskSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		resp        string
+		wantPerson  bool
+		wantTaskErr *TaskError
+		wantErr     error
+	}{
+		{
+			name:       "Successful",
+			resp:       `{"id": 1, "gender": "male", "height": 180}`,
+			wantPerson: true,
+		},
+		{
+			name: "Failed sub task",
+			resp: `{"is_ready": true, "is_successful": false, "sub_tasks": [
+	{"name": "side_processing", "status": "FAILURE", "task_id": "1", "message": "The body is not full", "code": 1007}
+]}`,
+			wantTaskErr: &TaskError{Code: SubTaskErrorCodeBodyIsNotFull, AppCode: "1007", SubTask: SubTaskNameSideProcessing, Side: PhotoSideSide, Message: "The body is not full"},
+		},
+		{
+			name:    "Failed without sub task error",
+			resp:    `{"is_ready": true, "is_successful": false, "sub_tasks": []}`,
+			wantErr: ErrTaskSetFailed,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := mockPersonAPI(t, tt.resp, http.StatusOK)
+			got, err := WaitForTaskSet(context.Background(), m, "4d563d3f-38ae-4b51-8eab-2b78483b153e", time.Millisecond)
+			if got == nil {
+				t.Fatalf("WaitForTaskSet() = nil, error = %v", err)
+			}
+			if (got.Person != nil) != tt.wantPerson {
+				t.Errorf("WaitForTaskSet() person = %+v, want person %v", got.Person, tt.wantPerson)
+			}
+			if !tt.wantPerson && got.TaskSet == nil {
+				t.Errorf("WaitForTaskSet() didn't return the failed task set")
+			}
+			var taskErr *TaskError
+			errors.As(err, &taskErr)
+			if diff := cmp.Diff(taskErr, tt.wantTaskErr); diff != "" {
+				t.Errorf("WaitForTaskSet() TaskError (-got, +want)\n%s", diff)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("WaitForTaskSet() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantPerson && err != nil {
+				t.Errorf("WaitForTaskSet() error = %v", err)
+			}
+		})
+	}
+}
+
 func mockPersonAPI(t *testing.T, response string, status int) *personAPI {
 	t.Helper()
 
@@ -0,0 +1,54 @@
+package saia
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPerson_PrimaryTaskSet(t *testing.T) {
+	t.Parallel()
+
+	older := &PersonTaskSet{ID: 1, IsSuccessful: true, Created: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)}
+	newer := &PersonTaskSet{ID: 2, IsSuccessful: true, Created: time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC)}
+	failed := &PersonTaskSet{ID: 3, IsReady: true, Created: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)}
+	primary := &PersonTaskSet{ID: 4, IsSuccessful: true, IsPrimary: true, Created: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name                 string
+		taskSets             []*PersonTaskSet
+		wantPrimary          *PersonTaskSet
+		wantLatestSuccessful *PersonTaskSet
+	}{
+		{name: "No task sets"},
+		{
+			name:                 "Primary task set",
+			taskSets:             []*PersonTaskSet{older, primary, newer, failed},
+			wantPrimary:          primary,
+			wantLatestSuccessful: newer,
+		},
+		{
+			name:                 "Fallback to latest successful task set",
+			taskSets:             []*PersonTaskSet{newer, failed, older},
+			wantPrimary:          newer,
+			wantLatestSuccessful: newer,
+		},
+		{
+			name:     "Only failed task sets",
+			taskSets: []*PersonTaskSet{failed},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Person{TaskSets: tt.taskSets}
+			if got := p.PrimaryTaskSet(); got != tt.wantPrimary {
+				t.Errorf("PrimaryTaskSet() = %v, want %v", got, tt.wantPrimary)
+			}
+			if got := p.LatestSuccessfulTaskSet(); got != tt.wantLatestSuccessful {
+				t.Errorf("LatestSuccessfulTaskSet() = %v, want %v", got, tt.wantLatestSuccessful)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package saia
+
+import (
+	"fmt"
+	"math"
+)
+
+// Severity is how suspicious the anomaly is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	// SeverityCritical means the result is almost certainly broken and should be reviewed manually
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Anomaly is an implausible value found in the measurements of a person.
+type Anomaly struct {
+	// Rule is the name of the rule which found the anomaly
+	Rule     string
+	Severity Severity
+	// Key is the measurement which is implausible, e.g. "volume.waist"
+	Key     MeasurementKey
+	Message string
+}
+
+func (a *Anomaly) String() string {
+	return fmt.Sprintf("[%s] %s: %s", a.Severity, a.Rule, a.Message)
+}
+
+// Rule checks the measurements of a person and returns the anomalies found.
+type Rule interface {
+	Name() string
+	Check(p *Person) []*Anomaly
+}
+
+// RuleFunc is an adapter to use a function as a Rule.
+func RuleFunc(name string, check func(p *Person) []*Anomaly) Rule {
+	return &ruleFunc{name: name, check: check}
+}
+
+type ruleFunc struct {
+	name  string
+	check func(p *Person) []*Anomaly
+}
+
+func (r *ruleFunc) Name() string {
+	return r.name
+}
+
+func (r *ruleFunc) Check(p *Person) []*Anomaly {
+	anomalies := r.check(p)
+	for _, a := range anomalies {
+		if a.Rule == "" {
+			a.Rule = r.name
+		}
+	}
+	return anomalies
+}
+
+// Names of the built-in rules
+const (
+	RuleMissingMeasurement = "missing_measurement"
+	RuleBodyHeight         = "body_height"
+	RuleLegLength          = "leg_length"
+	RuleWaistToChest       = "waist_to_chest"
+	RuleUnderBustToChest   = "under_bust_to_chest"
+	RuleGirthToHeight      = "girth_to_height"
+)
+
+// DefaultRules returns the built-in anthropometric sanity rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		RuleFunc(RuleMissingMeasurement, checkMissingMeasurement),
+		RuleFunc(RuleBodyHeight, checkBodyHeight),
+		RuleFunc(RuleLegLength, checkLegLength),
+		RuleFunc(RuleWaistToChest, checkWaistToChest),
+		RuleFunc(RuleUnderBustToChest, checkUnderBustToChest),
+		RuleFunc(RuleGirthToHeight, checkGirthToHeight),
+	}
+}
+
+// Validator checks the measurements of persons with the rules.
+type Validator struct {
+	rules []Rule
+}
+
+// NewValidator creates a new Validator with the rules.
+// Use append(DefaultRules(), customRules...) to add custom rules to the built-in ones.
+func NewValidator(rules ...Rule) *Validator {
+	return &Validator{rules: rules}
+}
+
+// Validate returns the anomalies found by the rules.
+// It returns nil when no params are calculated, e.g. the task set of the person is not successful.
+// The rules are applied to the calculated params regardless of the task set.
+func (v *Validator) Validate(p *Person) []*Anomaly {
+	if p.FrontParams == nil && p.SideParams == nil && p.VolumeParams == nil {
+		return nil
+	}
+	var anomalies []*Anomaly
+	for _, r := range v.rules {
+		anomalies = append(anomalies, r.Check(p)...)
+	}
+	return anomalies
+}
+
+var defaultValidator = NewValidator(DefaultRules()...)
+
+// Validate returns the anomalies found by the built-in rules.
+func Validate(p *Person) []*Anomaly {
+	return defaultValidator.Validate(p)
+}
+
+// MaxSeverity returns the highest severity of the anomalies,
+// it's useful to decide whether to route the result to manual review.
+func MaxSeverity(anomalies []*Anomaly) (Severity, bool) {
+	if len(anomalies) == 0 {
+		return SeverityInfo, false
+	}
+	max := anomalies[0].Severity
+	for _, a := range anomalies[1:] {
+		if a.Severity > max {
+			max = a.Severity
+		}
+	}
+	return max, true
+}
+
+func checkMissingMeasurement(p *Person) []*Anomaly {
+	var required []*paramValue
+	if p.FrontParams != nil {
+		required = append(required,
+			&paramValue{key: MeasurementKeyFrontBodyHeight, value: p.FrontParams.BodyHeight},
+			&paramValue{key: MeasurementKeyFrontInseam, value: p.FrontParams.Inseam},
+			&paramValue{key: MeasurementKeyFrontOutseam, value: p.FrontParams.Outseam},
+			&paramValue{key: MeasurementKeyFrontShoulders, value: p.FrontParams.Shoulders},
+			&paramValue{key: MeasurementKeyFrontSleeveLength, value: p.FrontParams.SleeveLength},
+		)
+	}
+	if p.VolumeParams != nil {
+		required = append(required,
+			&paramValue{key: MeasurementKeyVolumeChest, value: p.VolumeParams.Chest},
+			&paramValue{key: MeasurementKeyVolumeWaist, value: p.VolumeParams.Waist},
+			&paramValue{key: MeasurementKeyVolumeLowHips, value: p.VolumeParams.LowHips},
+			&paramValue{key: MeasurementKeyVolumeNeck, value: p.VolumeParams.Neck},
+			&paramValue{key: MeasurementKeyVolumeThigh, value: p.VolumeParams.Thigh},
+		)
+	}
+
+	var anomalies []*Anomaly
+	for _, v := range required {
+		if v.value <= 0 || math.IsNaN(v.value) {
+			anomalies = append(anomalies, &Anomaly{
+				Severity: SeverityCritical,
+				Key:      v.key,
+				Message:  fmt.Sprintf("%s is %v", v.key, v.value),
+			})
+		}
+	}
+	return anomalies
+}
+
+func checkBodyHeight(p *Person) []*Anomaly {
+	if p.FrontParams == nil || p.FrontParams.BodyHeight <= 0 || p.Height <= 0 {
+		return nil
+	}
+	height := float64(p.Height)
+	ratio := math.Abs(p.FrontParams.BodyHeight-height) / height
+	var severity Severity
+	switch {
+	case ratio > 0.1:
+		severity = SeverityCritical
+	case ratio > 0.05:
+		severity = SeverityWarning
+	default:
+		return nil
+	}
+	return []*Anomaly{{
+		Severity: severity,
+		Key:      MeasurementKeyFrontBodyHeight,
+		Message:  fmt.Sprintf("body height %.1f differs from the input height %d by %.0f%%", p.FrontParams.BodyHeight, p.Height, ratio*100),
+	}}
+}
+
+func checkLegLength(p *Person) []*Anomaly {
+	if p.FrontParams == nil || p.Height <= 0 {
+		return nil
+	}
+	height := float64(p.Height)
+	var anomalies []*Anomaly
+	for _, v := range []*paramValue{
+		{key: MeasurementKeyFrontInseam, value: p.FrontParams.Inseam},
+		{key: MeasurementKeyFrontOutseam, value: p.FrontParams.Outseam},
+	} {
+		if v.value <= 0 {
+			continue
+		}
+		switch {
+		case v.value >= height:
+			anomalies = append(anomalies, &Anomaly{
+				Severity: SeverityCritical,
+				Key:      v.key,
+				Message:  fmt.Sprintf("%s %.1f is not shorter than the height %d", v.key, v.value, p.Height),
+			})
+		case v.key == MeasurementKeyFrontInseam && (v.value < height*0.35 || v.value > height*0.55):
+			anomalies = append(anomalies, &Anomaly{
+				Severity: SeverityWarning,
+				Key:      v.key,
+				Message:  fmt.Sprintf("inseam %.1f is %.0f%% of the height %d", v.value, v.value/height*100, p.Height),
+			})
+		}
+	}
+	return anomalies
+}
+
+func checkWaistToChest(p *Person) []*Anomaly {
+	if p.VolumeParams == nil || p.VolumeParams.Chest <= 0 || p.VolumeParams.Waist <= 0 {
+		return nil
+	}
+	ratio := p.VolumeParams.Waist / p.VolumeParams.Chest
+	// waist can be larger than chest for persons with high BMI, but not by this much
+	maxRatio := 1.4
+	if bmi := p.bmi(); bmi > 0 && bmi < 30 {
+		maxRatio = 1.2
+	}
+	if ratio <= maxRatio && ratio >= 0.5 {
+		return nil
+	}
+	return []*Anomaly{{
+		Severity: SeverityCritical,
+		Key:      MeasurementKeyVolumeWaist,
+		Message:  fmt.Sprintf("waist %.1f is %.0f%% of chest %.1f", p.VolumeParams.Waist, ratio*100, p.VolumeParams.Chest),
+	}}
+}
+
+func checkUnderBustToChest(p *Person) []*Anomaly {
+	if p.VolumeParams == nil || p.VolumeParams.Chest <= 0 || p.VolumeParams.UnderBustGirth <= 0 {
+		return nil
+	}
+	if p.VolumeParams.UnderBustGirth <= p.VolumeParams.Chest {
+		return nil
+	}
+	return []*Anomaly{{
+		Severity: SeverityCritical,
+		Key:      MeasurementKeyVolumeUnderBustGirth,
+		Message:  fmt.Sprintf("under bust girth %.1f is larger than chest %.1f", p.VolumeParams.UnderBustGirth, p.VolumeParams.Chest),
+	}}
+}
+
+func checkGirthToHeight(p *Person) []*Anomaly {
+	if p.VolumeParams == nil || p.Height <= 0 {
+		return nil
+	}
+	height := float64(p.Height)
+	// chest of women includes the bust, so the upper bound is higher
+	maxChestRatio := 0.85
+	if p.Gender == GenderFemale {
+		maxChestRatio = 0.95
+	}
+	var anomalies []*Anomaly
+	for _, r := range []struct {
+		key      MeasurementKey
+		value    float64
+		min, max float64
+	}{
+		{key: MeasurementKeyVolumeChest, value: p.VolumeParams.Chest, min: 0.4, max: maxChestRatio},
+		{key: MeasurementKeyVolumeWaist, value: p.VolumeParams.Waist, min: 0.3, max: 0.85},
+		{key: MeasurementKeyVolumeLowHips, value: p.VolumeParams.LowHips, min: 0.4, max: 0.95},
+	} {
+		if r.value <= 0 {
+			continue
+		}
+		if ratio := r.value / height; ratio < r.min || ratio > r.max {
+			anomalies = append(anomalies, &Anomaly{
+				Severity: SeverityWarning,
+				Key:      r.key,
+				Message:  fmt.Sprintf("%s %.1f is %.0f%% of the height %d", r.key, r.value, ratio*100, p.Height),
+			})
+		}
+	}
+	return anomalies
+}
+
+// bmi returns the body mass index calculated from the input height and weight.
+func (p *Person) bmi() float64 {
+	if p.Height <= 0 || p.Weight <= 0 {
+		return 0
+	}
+	m := float64(p.Height) / 100
+	return p.Weight / (m * m)
+}
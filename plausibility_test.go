@@ -0,0 +1,109 @@
+package saia
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func plausiblePerson() *Person {
+	return &Person{
+		Gender: GenderMale,
+		Height: 180,
+		Weight: 75,
+		FrontParams: &FrontParams{
+			BodyHeight:   179,
+			Inseam:       82,
+			Outseam:      108,
+			Shoulders:    46,
+			SleeveLength: 62,
+		},
+		VolumeParams: &VolumeParams{
+			Chest:          100,
+			UnderBustGirth: 90,
+			Waist:          84,
+			LowHips:        98,
+			Neck:           38,
+			Thigh:          56,
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(p *Person)
+		want   []*Anomaly
+	}{
+		{
+			name:   "Plausible",
+			modify: func(p *Person) {},
+		},
+		{
+			name:   "Not calculated",
+			modify: func(p *Person) { p.FrontParams, p.VolumeParams = nil, nil },
+		},
+		{
+			name:   "Zero measurement",
+			modify: func(p *Person) { p.VolumeParams.Neck = 0 },
+			want: []*Anomaly{
+				{Rule: RuleMissingMeasurement, Severity: SeverityCritical, Key: MeasurementKeyVolumeNeck, Message: "volume.neck is 0"},
+			},
+		},
+		{
+			name:   "Inseam longer than height",
+			modify: func(p *Person) { p.FrontParams.Inseam = 185 },
+			want: []*Anomaly{
+				{Rule: RuleLegLength, Severity: SeverityCritical, Key: MeasurementKeyFrontInseam, Message: "front.inseam 185.0 is not shorter than the height 180"},
+			},
+		},
+		{
+			name:   "Waist larger than chest by 40%",
+			modify: func(p *Person) { p.VolumeParams.Waist = 140 },
+			want: []*Anomaly{
+				{Rule: RuleWaistToChest, Severity: SeverityCritical, Key: MeasurementKeyVolumeWaist, Message: "waist 140.0 is 140% of chest 100.0"},
+			},
+		},
+		{
+			name:   "Body height differs from input height",
+			modify: func(p *Person) { p.FrontParams.BodyHeight = 170 },
+			want: []*Anomaly{
+				{Rule: RuleBodyHeight, Severity: SeverityWarning, Key: MeasurementKeyFrontBodyHeight, Message: "body height 170.0 differs from the input height 180 by 6%"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := plausiblePerson()
+			tt.modify(p)
+			if diff := cmp.Diff(Validate(p), tt.want); diff != "" {
+				t.Errorf("Validate() (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidator_CustomRule(t *testing.T) {
+	t.Parallel()
+
+	v := NewValidator(append(DefaultRules(), RuleFunc("min_height", func(p *Person) []*Anomaly {
+		if p.Height < 190 {
+			return []*Anomaly{{Severity: SeverityInfo, Message: "short"}}
+		}
+		return nil
+	}))...)
+
+	got := v.Validate(plausiblePerson())
+	want := []*Anomaly{{Rule: "min_height", Severity: SeverityInfo, Message: "short"}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Validate() (-got, +want)\n%s", diff)
+	}
+	if severity, ok := MaxSeverity(got); !ok || severity != SeverityInfo {
+		t.Errorf("MaxSeverity() = %v, %v", severity, ok)
+	}
+}
@@ -0,0 +1,42 @@
+// Package saiatest provides utilities for testing code using saia.
+package saiatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/shing-dev/saia-go"
+)
+
+// NewWebhookPayload builds the webhook payload of the event and its signature.
+func NewWebhookPayload(secret string, eventType saia.WebhookEventType, data any) (payload []byte, signature string, err error) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	payload, err = json.Marshal(&saia.WebhookPayload{
+		Event:   eventType,
+		Created: time.Now().UTC(),
+		Data:    dataJSON,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, saia.SignWebhookPayload(secret, payload), nil
+}
+
+// NewWebhookRequest builds a signed webhook request of the event to be served by saia.WebhookHandler.
+// It panics when data can't be marshaled to JSON.
+func NewWebhookRequest(secret string, eventType saia.WebhookEventType, data any) *http.Request {
+	payload, signature, err := NewWebhookPayload(secret, eventType, data)
+	if err != nil {
+		panic("saiatest: marshal webhook payload: " + err.Error())
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(saia.WebhookSignatureHeader, signature)
+	return req
+}
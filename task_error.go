@@ -0,0 +1,158 @@
+package saia
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTaskSetFailed is the error of the failed task set which has no failed sub task.
+var ErrTaskSetFailed = errors.New("task set failed")
+
+// TaskSetErr returns the error of the finished task set without the person, i.e. the failed one.
+// It's the *TaskError of the failed sub tasks, or ErrTaskSetFailed when no sub task reports the failure.
+func TaskSetErr(t *TaskSet) error {
+	if err := t.Err(); err != nil {
+		return err
+	}
+	return ErrTaskSetFailed
+}
+
+// PhotoSide is the photo which caused the task error.
+type PhotoSide string
+
+const (
+	PhotoSideFront PhotoSide = "front"
+	PhotoSideSide  PhotoSide = "side"
+)
+
+// TaskError is the error of a failed sub task.
+// Use errors.As to extract it from the error returned by TaskSet.Err.
+type TaskError struct {
+	Code SubTaskErrorCode
+	// AppCode is the application error code returned by the API, it's empty when the API doesn't provide one
+	AppCode AppErrorCode
+	SubTask SubTaskName
+	// Side is the photo which caused the error, it's empty when it can't be determined
+	Side    PhotoSide
+	Message string
+}
+
+func (e *TaskError) Error() string {
+	if e.Side == "" {
+		return fmt.Sprintf("%s failed: %s", e.SubTask, e.Message)
+	}
+	return fmt.Sprintf("%s failed on the %s photo: %s", e.SubTask, e.Side, e.Message)
+}
+
+// Hint returns the remediation hint in English for the end user.
+func (e *TaskError) Hint() string {
+	return e.LocalizedHint("en")
+}
+
+// LocalizedHint returns the remediation hint for the end user in the language, e.g. "ja" or "de-DE".
+// It falls back to English when the hint is not translated into the language.
+func (e *TaskError) LocalizedHint(lang string) string {
+	return userMessage(lang, e.Code)
+}
+
+// Err returns the error of the sub task as *TaskError, it returns nil when the sub task is not failed.
+func (s *SubTask) Err() error {
+	if !s.IsFailed() {
+		return nil
+	}
+	code := s.ErrorCode()
+	return &TaskError{
+		Code:    code,
+		AppCode: s.AppCode,
+		SubTask: s.Name,
+		Side:    photoSide(s.Name, code),
+		Message: s.Message,
+	}
+}
+
+func subTasksErr(subTasks []*SubTask) error {
+	var errs []error
+	for _, s := range subTasks {
+		if err := s.Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func photoSide(name SubTaskName, code SubTaskErrorCode) PhotoSide {
+	switch {
+	case strings.HasPrefix(string(name), "front_"):
+		return PhotoSideFront
+	case strings.HasPrefix(string(name), "side_"):
+		return PhotoSideSide
+	}
+	switch code {
+	case SubTaskErrorCodeSidePhotoInTheFront:
+		return PhotoSideFront
+	case SubTaskErrorCodeFrontPhotoInTheSide:
+		return PhotoSideSide
+	default:
+		return ""
+	}
+}
+
+// AppErrorCode is the application error code of the failed sub task.
+// It's decoded from either a JSON number or string, since the API doesn't specify the type of the code.
+type AppErrorCode string
+
+func (c *AppErrorCode) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*c = AppErrorCode(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("application error code must be a number or string: %s", data)
+	}
+	*c = AppErrorCode(n)
+	return nil
+}
+
+// appErrorCodes maps the application error codes documented in https://saia.3dlook.me/docs/#task-errors to the error codes.
+// Only the documented codes may be added here, the others are classified by the message with errorMessages.
+var appErrorCodes = map[AppErrorCode]SubTaskErrorCode{}
+
+// errorMessages is the table of the known error messages, add new messages here when the API returns them.
+var errorMessages = []struct {
+	message string
+	// prefix is true when the message has details after it, e.g. "The pose is wrong, check: ..."
+	prefix bool
+	code   SubTaskErrorCode
+}{
+	{message: "The pose is wrong", prefix: true, code: SubTaskErrorCodeWrongPose},
+	{message: "Can't detect the human body", code: SubTaskErrorCodeHumanBodyNotDetected},
+	{message: "We cannot find a person in the photo", code: SubTaskErrorCodeHumanBodyNotDetected},
+	{message: "The detected object is not human", code: SubTaskErrorCodeObjectIsNotHuman},
+	{message: "Side photo in the front", code: SubTaskErrorCodeSidePhotoInTheFront},
+	{message: "Front photo in the side", code: SubTaskErrorCodeFrontPhotoInTheSide},
+	{message: "The body is not full", code: SubTaskErrorCodeBodyIsNotFull},
+	{message: "Failed to determine looking side", code: SubTaskErrorCodeDetermineLookingSideFailed},
+}
+
+func subTaskErrorCode(appCode AppErrorCode, message string) SubTaskErrorCode {
+	if code, ok := appErrorCodes[appCode]; ok {
+		return code
+	}
+	for _, m := range errorMessages {
+		if message == m.message || (m.prefix && strings.HasPrefix(message, m.message)) {
+			return m.code
+		}
+	}
+	return SubTaskErrorCodeUnknown
+}
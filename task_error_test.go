@@ -0,0 +1,127 @@
+package saia
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSubTask_Err(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		subTask *SubTask
+		want    *TaskError
+	}{
+		{
+			name:    "Not failed",
+			subTask: &SubTask{Name: SubTaskNameFrontProcessing, Status: TaskStatusSuccess},
+		},
+		{
+			name:    "Undocumented application error code falls back to the message",
+			subTask: &SubTask{Name: SubTaskNameSideProcessing, Status: TaskStatusFailure, AppCode: "1007", Message: "The body is not full"},
+			want:    &TaskError{Code: SubTaskErrorCodeBodyIsNotFull, AppCode: "1007", SubTask: SubTaskNameSideProcessing, Side: PhotoSideSide, Message: "The body is not full"},
+		},
+		{
+			name:    "Message with details",
+			subTask: &SubTask{Name: SubTaskNameFrontSkeletonProcessing, Status: TaskStatusFailure, Message: "The pose is wrong, check: left hand"},
+			want:    &TaskError{Code: SubTaskErrorCodeWrongPose, SubTask: SubTaskNameFrontSkeletonProcessing, Side: PhotoSideFront, Message: "The pose is wrong, check: left hand"},
+		},
+		{
+			name:    "Side from the error code",
+			subTask: &SubTask{Name: SubTaskNameMeasurementModelProcessing, Status: TaskStatusFailure, Message: "Front photo in the side"},
+			want:    &TaskError{Code: SubTaskErrorCodeFrontPhotoInTheSide, SubTask: SubTaskNameMeasurementModelProcessing, Side: PhotoSideSide, Message: "Front photo in the side"},
+		},
+		{
+			name:    "Unknown application error code and message",
+			subTask: &SubTask{Name: SubTaskNameMeasurementModelProcessing, Status: TaskStatusFailure, AppCode: "new_code", Message: "Something new"},
+			want:    &TaskError{Code: SubTaskErrorCodeUnknown, AppCode: "new_code", SubTask: SubTaskNameMeasurementModelProcessing, Message: "Something new"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.subTask.Err()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Err() = %v, want nil", err)
+				}
+				return
+			}
+			var got *TaskError
+			if !errors.As(err, &got) {
+				t.Fatalf("Err() = %v, want *TaskError", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Err() (-got, +want)\n%s", diff)
+			}
+			if got.Hint() == "" {
+				t.Errorf("Hint() is empty")
+			}
+		})
+	}
+}
+
+func TestTaskSet_Err(t *testing.T) {
+	t.Parallel()
+
+	taskSet := &TaskSet{
+		IsReady: true,
+		SubTasks: []*SubTask{
+			{Name: SubTaskNameFrontProcessing, Status: TaskStatusSuccess},
+			{Name: SubTaskNameSideProcessing, Status: TaskStatusFailure, Message: "The body is not full"},
+		},
+	}
+	err := taskSet.Err()
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("Err() = %v, want *TaskError", err)
+	}
+	if taskErr.Code != SubTaskErrorCodeBodyIsNotFull || taskErr.Side != PhotoSideSide {
+		t.Errorf("Err() = %+v", taskErr)
+	}
+	if got, want := err.Error(), "side_processing failed on the side photo: The body is not full"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if err := (&TaskSet{IsSuccessful: true, IsReady: true}).Err(); err != nil {
+		t.Errorf("Err() of successful task set = %v, want nil", err)
+	}
+}
+
+func TestAppErrorCode_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    AppErrorCode
+		wantErr bool
+	}{
+		{name: "Number", data: `{"code": 1007}`, want: "1007"},
+		{name: "String", data: `{"code": "body_is_not_full"}`, want: "body_is_not_full"},
+		{name: "Null", data: `{"code": null}`},
+		{name: "Missing", data: `{}`},
+		{name: "Object", data: `{"code": {}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got SubTask
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.AppCode != tt.want {
+				t.Errorf("AppCode = %q, want %q", got.AppCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package saia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Operation is an API operation which has its own timeout.
+type Operation string
+
+const (
+	OperationGetPerson              Operation = "GetPerson"
+	OperationListPersons            Operation = "ListPersons"
+	OperationCreatePerson           Operation = "CreatePerson"
+	OperationCreatePersonWithImages Operation = "CreatePersonWithImages"
+	OperationStartCalculation       Operation = "StartCalculation"
+	OperationGetTaskSet             Operation = "GetTaskSet"
+	// OperationUpdatePerson is the operations to update a person, e.g. ArchivePerson and UpdatePersonNotes
+	OperationUpdatePerson Operation = "UpdatePerson"
+	// OperationPartialUpdatePerson is the operation to upload the photos of a person
+	OperationPartialUpdatePerson   Operation = "PartialUpdatePerson"
+	OperationDeletePerson          Operation = "DeletePerson"
+	OperationGetMeasurementList    Operation = "GetMeasurementList"
+	OperationGetMeasurement        Operation = "GetMeasurement"
+	OperationCreateMeasurement     Operation = "CreateMeasurement"
+	OperationResendMeasurementLink Operation = "ResendMeasurementLink"
+	// OperationUpdateMeasurement is the operations to update a measurement, e.g. ArchiveMeasurement and UpdateMeasurementNotes
+	OperationUpdateMeasurement Operation = "UpdateMeasurement"
+	OperationDeleteMeasurement Operation = "DeleteMeasurement"
+	OperationGetMtmClientList  Operation = "GetMtmClientList"
+	OperationGetMtmClient      Operation = "GetMtmClient"
+	OperationCreateMtmClient   Operation = "CreateMtmClient"
+	OperationUpdateMtmClient   Operation = "UpdateMtmClient"
+	OperationDeleteMtmClient   Operation = "DeleteMtmClient"
+)
+
+// ErrTimeout is matched by errors.Is when the operation is timed out by the client.
+var ErrTimeout = errors.New("operation timed out")
+
+// TimeoutError is the error of the operation timed out by the timeout of the client.
+// It's distinguishable from the cancellation and the deadline of the caller's context by errors.As or errors.Is(err, ErrTimeout).
+type TimeoutError struct {
+	Operation Operation
+	Timeout   time.Duration
+	Err       error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s: %v", e.Operation, e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+type operationTimeoutKey struct{}
+
+type operationTimeout struct {
+	operation Operation
+	timeout   time.Duration
+	parent    context.Context
+}
+
+// withTimeout applies the timeout of the operation to ctx unless ctx has an earlier deadline.
+func (a *apiClient) withTimeout(ctx context.Context, op Operation) (context.Context, context.CancelFunc) {
+	timeout, ok := a.operationTimeouts[op]
+	if !ok {
+		timeout = a.timeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}
+	}
+	parent := ctx
+	ctx = context.WithValue(ctx, operationTimeoutKey{}, &operationTimeout{operation: op, timeout: timeout, parent: parent})
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutErr returns err as *TimeoutError when it's caused by the timeout applied by withTimeout.
+func timeoutErr(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	t, ok := ctx.Value(operationTimeoutKey{}).(*operationTimeout)
+	if !ok || t.parent.Err() != nil {
+		return err
+	}
+	return &TimeoutError{Operation: t.operation, Timeout: t.timeout, Err: err}
+}
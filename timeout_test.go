@@ -0,0 +1,91 @@
+package saia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_apiClient_Timeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		timeouts    map[Operation]time.Duration
+		ctx         func() (context.Context, context.CancelFunc)
+		wantTimeout bool
+		wantErr     error
+	}{
+		{
+			name:     "Operation timeout",
+			timeouts: map[Operation]time.Duration{OperationGetPerson: 20 * time.Millisecond},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			wantTimeout: true,
+			wantErr:     context.DeadlineExceeded,
+		},
+		{
+			name:     "Earlier deadline of the caller",
+			timeouts: map[Operation]time.Duration{OperationGetPerson: time.Minute},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:     "Cancellation of the caller",
+			timeouts: map[Operation]time.Duration{OperationGetPerson: time.Minute},
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			})
+			s := httptest.NewServer(h)
+			defer s.Close()
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, operationTimeouts: tt.timeouts}}
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			_, err := m.GetPerson(ctx, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPerson() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrTimeout); got != tt.wantTimeout {
+				t.Errorf("errors.Is(err, ErrTimeout) = %v, want %v", got, tt.wantTimeout)
+			}
+			var timeoutErr *TimeoutError
+			if errors.As(err, &timeoutErr) && timeoutErr.Operation != OperationGetPerson {
+				t.Errorf("TimeoutError.Operation = %s, want %s", timeoutErr.Operation, OperationGetPerson)
+			}
+		})
+	}
+}
+
+func Test_newDefaultClientOptions_UploadTimeouts(t *testing.T) {
+	t.Parallel()
+
+	opts := newDefaultClientOptions()
+	for _, op := range []Operation{OperationCreatePersonWithImages, OperationPartialUpdatePerson} {
+		if got := opts.OperationTimeouts[op]; got != 2*time.Minute {
+			t.Errorf("OperationTimeouts[%s] = %v, want %v", op, got, 2*time.Minute)
+		}
+	}
+}
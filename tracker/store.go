@@ -0,0 +1,200 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Job is a submitted task set tracked until it's finished.
+type Job struct {
+	TaskSetID string `json:"task_set_id"`
+	// Metadata is arbitrary data of the caller to correlate the job, e.g. the ID of the shopper
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Submitted time.Time         `json:"submitted"`
+}
+
+// Store persists the jobs in flight.
+type Store interface {
+	// Put adds or replaces the job of the task set
+	Put(ctx context.Context, job *Job) error
+	// Delete removes the job of the task set, it's not an error when the job doesn't exist
+	Delete(ctx context.Context, taskSetID string) error
+	// List returns all the jobs in the order of submission
+	List(ctx context.Context) ([]*Job, error)
+}
+
+// MemoryStore is a Store which keeps the jobs in memory, it's useful for tests and single process workers.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: map[string]*Job{}}
+}
+
+func (s *MemoryStore) Put(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.TaskSetID] = job
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, taskSetID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, taskSetID)
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedJobs(s.jobs), nil
+}
+
+type fileRecord struct {
+	Job *Job `json:"job,omitempty"`
+	// Deleted is the task set ID of the deleted job
+	Deleted string `json:"deleted,omitempty"`
+}
+
+// FileStore is a Store which persists the jobs in a file so that they survive restarts.
+// Changes are appended to the file as JSON lines and synced, and the file is compacted when it's opened.
+type FileStore struct {
+	mu   sync.Mutex
+	file *os.File
+	jobs map[string]*Job
+}
+
+// OpenFileStore opens the file store at path, the file is created when it doesn't exist.
+func OpenFileStore(path string) (*FileStore, error) {
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read store file: %w", err)
+	}
+	jobs := map[string]*Job{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var record fileRecord
+		// a partially written last line is skipped since the change was not acknowledged
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if record.Job != nil {
+			jobs[record.Job.TaskSetID] = record.Job
+		} else {
+			delete(jobs, record.Deleted)
+		}
+	}
+
+	if err := compact(path, jobs); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open store file: %w", err)
+	}
+	return &FileStore{file: file, jobs: jobs}, nil
+}
+
+// compact rewrites the file with only the live jobs and replaces it atomically.
+func compact(path string, jobs map[string]*Job) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp store file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, job := range sortedJobs(jobs) {
+		if err := enc.Encode(&fileRecord{Job: job}); err != nil {
+			return fmt.Errorf("write temp store file: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("write temp store file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("sync temp store file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp store file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace store file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Put(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(&fileRecord{Job: job}); err != nil {
+		return err
+	}
+	s.jobs[job.TaskSetID] = job
+	return nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, taskSetID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[taskSetID]; !ok {
+		return nil
+	}
+	if err := s.append(&fileRecord{Deleted: taskSetID}); err != nil {
+		return err
+	}
+	delete(s.jobs, taskSetID)
+	return nil
+}
+
+func (s *FileStore) List(ctx context.Context) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedJobs(s.jobs), nil
+}
+
+func (s *FileStore) append(record *fileRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("write store file: %w", err)
+	}
+	return s.file.Sync()
+}
+
+// Close closes the file of the store.
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
+
+func sortedJobs(jobs map[string]*Job) []*Job {
+	list := make([]*Job, 0, len(jobs))
+	for _, job := range jobs {
+		list = append(list, job)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Submitted.Equal(list[j].Submitted) {
+			return list[i].Submitted.Before(list[j].Submitted)
+		}
+		return list[i].TaskSetID < list[j].TaskSetID
+	})
+	return list
+}
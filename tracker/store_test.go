@@ -0,0 +1,67 @@
+package tracker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "jobs.jsonl")
+	submitted := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	s, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	for i, id := range []string{"a", "b", "c"} {
+		if err := s.Put(ctx, &Job{TaskSetID: id, Submitted: submitted.Add(time.Duration(i) * time.Second)}); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	if err := s.Delete(ctx, "b"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a crash in the middle of writing a record
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"job":{"task_set_id":"d"`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	s, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	defer s.Close()
+	if err := s.Put(ctx, &Job{TaskSetID: "e", Submitted: submitted.Add(time.Minute)}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []*Job{
+		{TaskSetID: "a", Submitted: submitted},
+		{TaskSetID: "c", Submitted: submitted.Add(2 * time.Second)},
+		{TaskSetID: "e", Submitted: submitted.Add(time.Minute)},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("List() (-got, +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,210 @@
+// Package tracker keeps track of the submitted task sets across restarts and notifies when they are finished.
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shing-dev/saia-go"
+)
+
+// ErrExpired is the error of the job which is not finished within the TTL.
+var ErrExpired = errors.New("task set is not finished within the ttl")
+
+// Status is the final status of a job.
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusExpired   Status = "expired"
+)
+
+// Event is emitted when a job is finished or expired.
+type Event struct {
+	Job    *Job
+	Status Status
+	// Person is the measured person when the task set is succeeded
+	Person *saia.Person
+	// TaskSet is the failed task set
+	TaskSet *saia.TaskSet
+	// Err is the error of the failed task set (see saia.TaskError) or ErrExpired
+	Err error
+}
+
+// Options is the configuration of Tracker.
+type Options struct {
+	// PollInterval is the interval to poll the task sets
+	PollInterval time.Duration
+	// TTL is the duration after the submission to give up the job, jobs never expire when it's 0
+	TTL time.Duration
+	// Concurrency is the max number of task sets polled at the same time
+	Concurrency int
+	// ErrorHandler is called when polling a task set fails, the job is retried in the next poll.
+	// job is nil when listing the jobs in the store fails in Run, the jobs are listed again in the next poll
+	ErrorHandler func(job *Job, err error)
+}
+
+func newDefaultOptions() *Options {
+	return &Options{
+		PollInterval: 3 * time.Second,
+		TTL:          24 * time.Hour,
+		Concurrency:  4,
+	}
+}
+
+// Option is a option to change tracker configuration.
+type Option func(*Options)
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.PollInterval = interval
+	}
+}
+
+func WithTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.TTL = ttl
+	}
+}
+
+func WithConcurrency(concurrency int) Option {
+	return func(o *Options) {
+		o.Concurrency = concurrency
+	}
+}
+
+func WithErrorHandler(f func(job *Job, err error)) Option {
+	return func(o *Options) {
+		o.ErrorHandler = f
+	}
+}
+
+// Tracker polls the task sets of the jobs in the store and emits the events when they are finished.
+type Tracker struct {
+	personAPI saia.PersonAPI
+	store     Store
+	onEvent   func(ctx context.Context, event *Event) error
+	opts      *Options
+	now       func() time.Time
+}
+
+// New creates a new Tracker. onEvent is called once the job is finished or expired,
+// the job is removed from the store only after onEvent returns nil, so the events are delivered at least once.
+func New(personAPI saia.PersonAPI, store Store, onEvent func(ctx context.Context, event *Event) error, opt ...Option) *Tracker {
+	opts := newDefaultOptions()
+	for _, o := range opt {
+		o(opts)
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	return &Tracker{
+		personAPI: personAPI,
+		store:     store,
+		onEvent:   onEvent,
+		opts:      opts,
+		now:       time.Now,
+	}
+}
+
+// Track records the submitted task set, e.g. CreatePersonWithImagesResponse.TaskSetID, to be polled by Run.
+func (t *Tracker) Track(ctx context.Context, taskSetID string, metadata map[string]string) error {
+	job := &Job{TaskSetID: taskSetID, Metadata: metadata, Submitted: t.now()}
+	if err := t.store.Put(ctx, job); err != nil {
+		return fmt.Errorf("put job: %w", err)
+	}
+	return nil
+}
+
+// Run polls the jobs in the store until ctx is done.
+// The jobs tracked before the restart are resumed at the first poll.
+// Errors of the store are reported to ErrorHandler and don't stop the tracking.
+func (t *Tracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if t.opts.ErrorHandler != nil {
+				t.opts.ErrorHandler(nil, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll polls all the jobs in the store once, it's useful to run the tracker from a scheduler.
+func (t *Tracker) Poll(ctx context.Context) error {
+	jobs, err := t.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list jobs: %w", err)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, t.opts.Concurrency)
+	)
+	for _, job := range jobs {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(job *Job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := t.poll(ctx, job); err != nil && ctx.Err() == nil && t.opts.ErrorHandler != nil {
+				t.opts.ErrorHandler(job, err)
+			}
+		}(job)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (t *Tracker) poll(ctx context.Context, job *Job) error {
+	event, err := t.check(ctx, job)
+	if err != nil || event == nil {
+		return err
+	}
+	if err := t.onEvent(ctx, event); err != nil {
+		return fmt.Errorf("handle event: %w", err)
+	}
+	if err := t.store.Delete(ctx, job.TaskSetID); err != nil {
+		return fmt.Errorf("delete job: %w", err)
+	}
+	return nil
+}
+
+// check returns the event of the job, it returns nil when the task set is still in progress.
+func (t *Tracker) check(ctx context.Context, job *Job) (*Event, error) {
+	if t.opts.TTL > 0 && t.now().Sub(job.Submitted) > t.opts.TTL {
+		return &Event{Job: job, Status: StatusExpired, Err: ErrExpired}, nil
+	}
+
+	resp, err := t.personAPI.GetTaskSet(ctx, job.TaskSetID)
+	if err != nil {
+		return nil, fmt.Errorf("get task set: %w", err)
+	}
+	switch {
+	case resp.Person != nil:
+		return &Event{Job: job, Status: StatusSucceeded, Person: resp.Person}, nil
+	case resp.TaskSet.IsReady:
+		return &Event{Job: job, Status: StatusFailed, TaskSet: resp.TaskSet, Err: saia.TaskSetErr(resp.TaskSet)}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,173 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shing-dev/saia-go"
+)
+
+type fakePersonAPI struct {
+	saia.PersonAPI
+}
+
+func (f *fakePersonAPI) GetTaskSet(ctx context.Context, taskSetID string) (*saia.GetTaskSetResponse, error) {
+	switch taskSetID {
+	case "succeeded":
+		return &saia.GetTaskSetResponse{Person: &saia.Person{ID: 1}}, nil
+	case "failed":
+		return &saia.GetTaskSetResponse{TaskSet: &saia.TaskSet{IsReady: true, SubTasks: []*saia.SubTask{
+			{Name: saia.SubTaskNameFrontProcessing, Status: saia.TaskStatusFailure, Message: "The body is not full"},
+		}}}, nil
+	case "broken":
+		return nil, errors.New("internal server error")
+	default:
+		return &saia.GetTaskSetResponse{TaskSet: &saia.TaskSet{}}, nil
+	}
+}
+
+func TestTracker_Poll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	for _, job := range []*Job{
+		{TaskSetID: "succeeded", Submitted: now.Add(-time.Minute), Metadata: map[string]string{"user_id": "u1"}},
+		{TaskSetID: "failed", Submitted: now.Add(-time.Minute)},
+		{TaskSetID: "pending", Submitted: now.Add(-time.Minute)},
+		{TaskSetID: "stale", Submitted: now.Add(-2 * time.Hour)},
+		{TaskSetID: "broken", Submitted: now.Add(-time.Minute)},
+	} {
+		if err := store.Put(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		mu        sync.Mutex
+		events    = map[string]*Event{}
+		errorJobs []string
+	)
+	tr := New(&fakePersonAPI{}, store, func(ctx context.Context, event *Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		events[event.Job.TaskSetID] = event
+		return nil
+	}, WithTTL(time.Hour), WithErrorHandler(func(job *Job, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errorJobs = append(errorJobs, job.TaskSetID)
+	}))
+	tr.now = func() time.Time { return now }
+
+	if err := tr.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	gotStatuses := map[string]Status{}
+	for id, event := range events {
+		gotStatuses[id] = event.Status
+	}
+	wantStatuses := map[string]Status{"succeeded": StatusSucceeded, "failed": StatusFailed, "stale": StatusExpired}
+	if diff := cmp.Diff(gotStatuses, wantStatuses); diff != "" {
+		t.Errorf("Poll() statuses (-got, +want)\n%s", diff)
+	}
+	if got := events["succeeded"]; got.Person.ID != 1 || got.Job.Metadata["user_id"] != "u1" {
+		t.Errorf("Poll() succeeded event = %+v", got)
+	}
+	var taskErr *saia.TaskError
+	if !errors.As(events["failed"].Err, &taskErr) || taskErr.Code != saia.SubTaskErrorCodeBodyIsNotFull {
+		t.Errorf("Poll() failed event error = %v, want TaskError", events["failed"].Err)
+	}
+	if !errors.Is(events["stale"].Err, ErrExpired) {
+		t.Errorf("Poll() stale event error = %v, want ErrExpired", events["stale"].Err)
+	}
+	if diff := cmp.Diff(errorJobs, []string{"broken"}); diff != "" {
+		t.Errorf("Poll() error jobs (-got, +want)\n%s", diff)
+	}
+
+	jobs, _ := store.List(ctx)
+	var remaining []string
+	for _, job := range jobs {
+		remaining = append(remaining, job.TaskSetID)
+	}
+	if diff := cmp.Diff(remaining, []string{"broken", "pending"}); diff != "" {
+		t.Errorf("remaining jobs (-got, +want)\n%s", diff)
+	}
+}
+
+func TestTracker_Poll_KeepsJobWhenEventHandlerFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	tr := New(&fakePersonAPI{}, store, func(ctx context.Context, event *Event) error {
+		return errors.New("queue is unavailable")
+	})
+	if err := tr.Track(ctx, "succeeded", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if jobs, _ := store.List(ctx); len(jobs) != 1 {
+		t.Errorf("jobs = %v, want the job kept to retry the event", jobs)
+	}
+}
+
+type flakyStore struct {
+	*MemoryStore
+
+	mu       sync.Mutex
+	failures int
+}
+
+func (s *flakyStore) List(ctx context.Context) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.New("store is unavailable")
+	}
+	return s.MemoryStore.List(ctx)
+}
+
+func TestTracker_Run_ContinuesWhenStoreFails(t *testing.T) {
+	t.Parallel()
+
+	store := &flakyStore{MemoryStore: NewMemoryStore(), failures: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		storeErrs int
+	)
+	tr := New(&fakePersonAPI{}, store, func(ctx context.Context, event *Event) error {
+		cancel()
+		return nil
+	}, WithPollInterval(time.Millisecond), WithErrorHandler(func(job *Job, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if job == nil {
+			storeErrs++
+		}
+	}))
+	if err := tr.Track(ctx, "succeeded", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tr.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if storeErrs != 1 {
+		t.Errorf("store errors = %d, want 1", storeErrs)
+	}
+}
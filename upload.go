@@ -0,0 +1,210 @@
+package saia
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"reflect"
+)
+
+// UploadMode is the transport to upload the person photos.
+type UploadMode string
+
+const (
+	// UploadModeJSON sends the photos encoded in base64 in the JSON body
+	UploadModeJSON UploadMode = "json"
+	// UploadModeMultipart streams the photos from the readers as the parts of multipart/form-data body,
+	// which is a third smaller than base64
+	UploadModeMultipart UploadMode = "multipart"
+)
+
+// formPart is a field or an image of the body uploading the photos.
+type formPart struct {
+	name  string
+	value any
+	// image is set instead of value for the image parts
+	image io.Reader
+}
+
+// encodeForm encodes the parts into the body of the upload mode and returns it with its content type.
+// The body must be closed by closeReader when it's not passed to the request.
+func encodeForm(mode UploadMode, parts []*formPart) (io.Reader, string, error) {
+	if mode == UploadModeMultipart {
+		body, contentType := encodeMultipartForm(parts)
+		return body, contentType, nil
+	}
+	b, err := encodeJSONForm(parts)
+	if err != nil {
+		return nil, "", err
+	}
+	return bytes.NewReader(b), "application/json", nil
+}
+
+// closeReader closes the body returned by encodeForm which is not sent,
+// e.g. to stop the goroutine streaming the multipart body.
+func closeReader(r io.Reader) {
+	if c, ok := r.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+func encodeJSONForm(parts []*formPart) ([]byte, error) {
+	m := make(map[string]any, len(parts))
+	for _, p := range parts {
+		if p.image == nil {
+			m[p.name] = p.value
+			continue
+		}
+		b, err := io.ReadAll(p.image)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", p.name, err)
+		}
+		m[p.name] = base64.StdEncoding.EncodeToString(b)
+	}
+	return json.Marshal(m)
+}
+
+// encodeMultipartForm streams the parts through a pipe, so the images are not loaded into memory.
+// Fields of the zero value are omitted.
+func encodeMultipartForm(parts []*formPart) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipartForm(mw, parts))
+	}()
+	return pr, mw.FormDataContentType()
+}
+
+func writeMultipartForm(mw *multipart.Writer, parts []*formPart) error {
+	for _, p := range parts {
+		if p.image != nil {
+			if err := writeImagePart(mw, p.name, p.image); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok, err := formValue(p.value)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", p.name, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := mw.WriteField(p.name, value); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func writeImagePart(mw *multipart.Writer, name string, image io.Reader) error {
+	br := bufio.NewReaderSize(image, 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	contentType := http.DetectContentType(head)
+
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s%s"`, name, name, imageExtension(contentType)))
+	h.Set("Content-Type", contentType)
+	w, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, br); err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	return nil
+}
+
+func imageExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+// formValue returns the value of the multipart field, ok is false when the value is zero.
+// Structs like DeviceCoordinates are encoded in JSON.
+func formValue(v any) (value string, ok bool, err error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.IsZero() {
+		return "", false, nil
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Struct, reflect.Map, reflect.Slice:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), true, nil
+	default:
+		return fmt.Sprint(v), true, nil
+	}
+}
+
+// progressReader reports the bytes of the request body read by the transport, i.e. written to the network.
+type progressReader struct {
+	r          io.ReadCloser
+	sent       int64
+	total      int64
+	onProgress func(sent, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.onProgress(p.sent, p.total)
+	}
+	// the total of the streamed body is known only at the end
+	if errors.Is(err, io.EOF) && p.total < 0 {
+		p.total = p.sent
+		p.onProgress(p.sent, p.total)
+	}
+	return n, err
+}
+
+func (p *progressReader) Close() error {
+	return p.r.Close()
+}
+
+// withUploadProgress reports the progress of sending the request body to onProgress.
+// total is -1 while the length of the body is unknown, e.g. the multipart body streamed from the readers.
+// onProgress is called from the goroutine reading the body, which is the goroutine of the transport,
+// not the caller's one. When the transport resends the body, e.g. for a redirect, the progress restarts from 0.
+func withUploadProgress(req *http.Request, onProgress func(sent, total int64)) {
+	if onProgress == nil || req.Body == nil {
+		return
+	}
+	total := req.ContentLength
+	if total <= 0 {
+		total = -1
+	}
+	req.Body = &progressReader{r: req.Body, total: total, onProgress: onProgress}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &progressReader{r: body, total: total, onProgress: onProgress}, nil
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package saia
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// receivedUpload is the request received by the fake server decoded regardless of the upload mode.
+type receivedUpload struct {
+	method       string
+	fields       map[string]string
+	images       map[string][]byte
+	contentTypes map[string]string
+}
+
+func newUploadServer(t *testing.T, resp string) (*httptest.Server, *receivedUpload) {
+	t.Helper()
+
+	got := &receivedUpload{fields: map[string]string{}, images: map[string][]byte{}, contentTypes: map[string]string{}}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.method = r.Method
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			mr, err := r.MultipartReader()
+			if err != nil {
+				t.Errorf("MultipartReader() error = %v", err)
+				return
+			}
+			for {
+				part, err := mr.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Errorf("NextPart() error = %v", err)
+					return
+				}
+				b, _ := io.ReadAll(part)
+				if part.FileName() != "" {
+					got.images[part.FormName()] = b
+					got.contentTypes[part.FormName()] = part.Header.Get("Content-Type")
+				} else {
+					got.fields[part.FormName()] = string(b)
+				}
+			}
+		} else {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Decode() error = %v", err)
+				return
+			}
+			for name, v := range body {
+				if strings.HasSuffix(name, "_image") {
+					got.images[name], _ = base64.StdEncoding.DecodeString(v.(string))
+				} else if v != nil && v != "" {
+					got.fields[name] = fmt.Sprint(v)
+				}
+			}
+		}
+		fmt.Fprintln(w, resp)
+	})
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	return s, got
+}
+
+func randomImage(header string, size int, seed int64) []byte {
+	b := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(b)
+	copy(b, header)
+	return b
+}
+
+func Test_personAPI_CreatePersonWithImages_UploadMode(t *testing.T) {
+	t.Parallel()
+
+	front := randomImage("\xff\xd8\xff", 300*1024, 1)
+	side := randomImage("\x89PNG\r\n\x1a\n", 200*1024, 2)
+
+	for _, mode := range []UploadMode{UploadModeJSON, UploadModeMultipart} {
+		mode := mode
+		t.Run(string(mode), func(t *testing.T) {
+			t.Parallel()
+
+			s, got := newUploadServer(t, `{"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/"}`)
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, uploadMode: mode}}
+
+			resp, err := m.CreatePersonWithImages(context.Background(), &CreatePersonWithImagesParams{
+				Gender:            GenderFemale,
+				Height:            170,
+				Weight:            60.5,
+				FrontImage:        bytes.NewReader(front),
+				SideImage:         bytes.NewReader(side),
+				DeviceCoordinates: &DeviceCoordinates{FrontPhoto: &DeviceCoordinate{BetaX: 90}},
+				PhotoFlowType:     PhotoFlowTypeHand,
+			})
+			if err != nil {
+				t.Fatalf("CreatePersonWithImages() error = %v", err)
+			}
+			if resp.TaskSetID != "4d563d3f-38ae-4b51-8eab-2b78483b153e" {
+				t.Errorf("CreatePersonWithImages() task set id = %s", resp.TaskSetID)
+			}
+
+			if !bytes.Equal(got.images["front_image"], front) || !bytes.Equal(got.images["side_image"], side) {
+				t.Errorf("received images are not identical to the sent ones")
+			}
+			wantFields := map[string]string{"gender": "female", "height": "170", "weight": "60.5", "photo_flow": "hand"}
+			gotFields := map[string]string{}
+			for name, v := range got.fields {
+				if name != "phone_position" {
+					gotFields[name] = v
+				}
+			}
+			if diff := cmp.Diff(gotFields, wantFields); diff != "" {
+				t.Errorf("received fields (-got, +want)\n%s", diff)
+			}
+			if mode == UploadModeMultipart {
+				if diff := cmp.Diff(got.contentTypes, map[string]string{"front_image": "image/jpeg", "side_image": "image/png"}); diff != "" {
+					t.Errorf("received content types (-got, +want)\n%s", diff)
+				}
+				if got, want := got.fields["phone_position"], `{"frontPhoto":{"betaX":90,"gammaY":0,"alphaZ":0},"sidePhoto":null}`; got != want {
+					t.Errorf("received phone_position = %s, want %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_personAPI_PartialUpdatePerson(t *testing.T) {
+	t.Parallel()
+
+	side := randomImage("\xff\xd8\xff", 100*1024, 3)
+	s, got := newUploadServer(t, `{"id": 1}`)
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, uploadMode: UploadModeMultipart}}
+
+	person, err := m.PartialUpdatePerson(context.Background(), 1, &PartialUpdatePersonParams{SideImage: bytes.NewReader(side)})
+	if err != nil {
+		t.Fatalf("PartialUpdatePerson() error = %v", err)
+	}
+	if person.ID != 1 {
+		t.Errorf("PartialUpdatePerson() id = %d, want 1", person.ID)
+	}
+	if got.method != http.MethodPatch {
+		t.Errorf("PartialUpdatePerson() method = %s, want PATCH", got.method)
+	}
+	if len(got.images) != 1 || !bytes.Equal(got.images["side_image"], side) {
+		t.Errorf("received images are not identical to the sent ones")
+	}
+	if len(got.fields) != 0 {
+		t.Errorf("received fields = %v, want none", got.fields)
+	}
+}
+
+func Test_personAPI_CreatePersonWithImages_UploadProgress(t *testing.T) {
+	t.Parallel()
+
+	front := randomImage("\xff\xd8\xff", 512*1024, 4)
+	side := randomImage("\xff\xd8\xff", 256*1024, 5)
+
+	for _, mode := range []UploadMode{UploadModeJSON, UploadModeMultipart} {
+		mode := mode
+		t.Run(string(mode), func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newUploadServer(t, `{"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/"}`)
+			m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, uploadMode: mode}}
+
+			type progress struct{ sent, total int64 }
+			var got []progress
+			_, err := m.CreatePersonWithImages(context.Background(), &CreatePersonWithImagesParams{
+				Gender:        GenderMale,
+				Height:        180,
+				Weight:        75,
+				FrontImage:    bytes.NewReader(front),
+				SideImage:     bytes.NewReader(side),
+				PhotoFlowType: PhotoFlowTypeFriend,
+				OnUploadProgress: func(sent, total int64) {
+					got = append(got, progress{sent: sent, total: total})
+				},
+			})
+			if err != nil {
+				t.Fatalf("CreatePersonWithImages() error = %v", err)
+			}
+
+			if len(got) < 2 {
+				t.Fatalf("OnUploadProgress is called %d times, want the progress of the upload", len(got))
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].sent < got[i-1].sent {
+					t.Errorf("sent decreased from %d to %d", got[i-1].sent, got[i].sent)
+				}
+			}
+			last := got[len(got)-1]
+			if last.sent != last.total || last.sent < int64(len(front)+len(side)) {
+				t.Errorf("last progress = %+v, want all the body sent", last)
+			}
+			// the length of the streamed multipart body is unknown until the end
+			wantTotal := last.total
+			if mode == UploadModeMultipart {
+				wantTotal = -1
+			}
+			if got[0].total != wantTotal {
+				t.Errorf("first progress total = %d, want %d", got[0].total, wantTotal)
+			}
+		})
+	}
+}
+
+func Test_personAPI_CreatePersonWithImages_UploadProgressRedirect(t *testing.T) {
+	t.Parallel()
+
+	var gotBodies []int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBodies = append(gotBodies, len(body))
+		if r.URL.Path == "/persons/" {
+			http.Redirect(w, r, "/moved"+r.URL.RequestURI(), http.StatusTemporaryRedirect)
+			return
+		}
+		fmt.Fprintln(w, `{"task_set_url": "https://saia.3dlook.me/api/v2/queue/4d563d3f-38ae-4b51-8eab-2b78483b153e/"}`)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	m := &personAPI{&apiClient{httpClient: http.DefaultClient, apiHost: s.URL, uploadMode: UploadModeJSON}}
+
+	var sent, total int64
+	_, err := m.CreatePersonWithImages(context.Background(), &CreatePersonWithImagesParams{
+		Gender:        GenderMale,
+		Height:        180,
+		Weight:        75,
+		FrontImage:    bytes.NewReader(randomImage("\xff\xd8\xff", 1024, 6)),
+		SideImage:     bytes.NewReader(randomImage("\xff\xd8\xff", 1024, 7)),
+		PhotoFlowType: PhotoFlowTypeFriend,
+		OnUploadProgress: func(s, t int64) {
+			sent, total = s, t
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePersonWithImages() error = %v", err)
+	}
+	// the body is resent to the redirected location
+	if len(gotBodies) != 2 || gotBodies[0] == 0 || gotBodies[1] != gotBodies[0] {
+		t.Errorf("received bodies of %v bytes, want the same body twice", gotBodies)
+	}
+	if sent != total || sent != int64(gotBodies[0]) {
+		t.Errorf("last progress = (%d, %d), want (%d, %d)", sent, total, gotBodies[0], gotBodies[0])
+	}
+}
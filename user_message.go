@@ -0,0 +1,113 @@
+package saia
+
+import (
+	"strings"
+	"sync"
+)
+
+// MessageCatalog is the end-user messages of a language keyed by the error code.
+type MessageCatalog map[SubTaskErrorCode]string
+
+// DefaultLanguage is the language used when the message is not translated into the requested language.
+const DefaultLanguage = "en"
+
+var (
+	messageCatalogsMu sync.RWMutex
+	messageCatalogs   = map[string]MessageCatalog{
+		"en": {
+			SubTaskErrorCodeUnknown:                    "Something went wrong. Please retake the photos and try again.",
+			SubTaskErrorCodeWrongPose:                  "Stand straight with your arms slightly away from your body and your feet shoulder-width apart, then retake the photo.",
+			SubTaskErrorCodeHumanBodyNotDetected:       "We couldn't find you in the photo. Stand in front of a plain background with your whole body in the frame.",
+			SubTaskErrorCodeObjectIsNotHuman:           "Please take a photo of a person, not an object or a picture.",
+			SubTaskErrorCodeSidePhotoInTheFront:        "Face the camera directly in the front photo.",
+			SubTaskErrorCodeFrontPhotoInTheSide:        "Turn 90 degrees to your side for the side photo.",
+			SubTaskErrorCodeDetermineLookingSideFailed: "Turn fully to the side so that your profile is clearly visible, then retake the photo.",
+			SubTaskErrorCodeBodyIsNotFull:              "Make sure your whole body is visible from head to toe, then retake the photo.",
+		},
+		"ja": {
+			SubTaskErrorCodeUnknown:                    "エラーが発生しました。写真を撮り直して、もう一度お試しください。",
+			SubTaskErrorCodeWrongPose:                  "まっすぐに立ち、腕を体から少し離し、足を肩幅に開いて撮り直してください。",
+			SubTaskErrorCodeHumanBodyNotDetected:       "写真から人物を検出できませんでした。無地の背景の前で、全身が写るように撮影してください。",
+			SubTaskErrorCodeObjectIsNotHuman:           "物や画像ではなく、人物を撮影してください。",
+			SubTaskErrorCodeSidePhotoInTheFront:        "正面の写真では、カメラに向かってまっすぐ立ってください。",
+			SubTaskErrorCodeFrontPhotoInTheSide:        "横向きの写真では、体を90度横に向けてください。",
+			SubTaskErrorCodeDetermineLookingSideFailed: "横顔がはっきり写るように完全に横を向いて撮り直してください。",
+			SubTaskErrorCodeBodyIsNotFull:              "頭からつま先まで全身が写るように撮り直してください。",
+		},
+		"de": {
+			SubTaskErrorCodeUnknown:                    "Etwas ist schiefgelaufen. Bitte nehmen Sie die Fotos erneut auf und versuchen Sie es noch einmal.",
+			SubTaskErrorCodeWrongPose:                  "Stehen Sie gerade, halten Sie die Arme leicht vom Körper weg und die Füße schulterbreit auseinander, und nehmen Sie das Foto erneut auf.",
+			SubTaskErrorCodeHumanBodyNotDetected:       "Wir konnten Sie auf dem Foto nicht erkennen. Stellen Sie sich vor einen einfarbigen Hintergrund, sodass Ihr ganzer Körper im Bild ist.",
+			SubTaskErrorCodeObjectIsNotHuman:           "Bitte fotografieren Sie eine Person, keinen Gegenstand und kein Bild.",
+			SubTaskErrorCodeSidePhotoInTheFront:        "Schauen Sie beim Frontalfoto direkt in die Kamera.",
+			SubTaskErrorCodeFrontPhotoInTheSide:        "Drehen Sie sich für das Seitenfoto um 90 Grad zur Seite.",
+			SubTaskErrorCodeDetermineLookingSideFailed: "Drehen Sie sich vollständig zur Seite, sodass Ihr Profil gut sichtbar ist, und nehmen Sie das Foto erneut auf.",
+			SubTaskErrorCodeBodyIsNotFull:              "Achten Sie darauf, dass Ihr ganzer Körper von Kopf bis Fuß sichtbar ist, und nehmen Sie das Foto erneut auf.",
+		},
+		"fr": {
+			SubTaskErrorCodeUnknown:                    "Une erreur s'est produite. Veuillez reprendre les photos et réessayer.",
+			SubTaskErrorCodeWrongPose:                  "Tenez-vous droit, les bras légèrement écartés du corps et les pieds écartés à la largeur des épaules, puis reprenez la photo.",
+			SubTaskErrorCodeHumanBodyNotDetected:       "Nous ne vous avons pas trouvé sur la photo. Placez-vous devant un fond uni, le corps entier dans le cadre.",
+			SubTaskErrorCodeObjectIsNotHuman:           "Veuillez photographier une personne, pas un objet ni une image.",
+			SubTaskErrorCodeSidePhotoInTheFront:        "Faites face à l'appareil pour la photo de face.",
+			SubTaskErrorCodeFrontPhotoInTheSide:        "Tournez-vous de 90 degrés sur le côté pour la photo de profil.",
+			SubTaskErrorCodeDetermineLookingSideFailed: "Tournez-vous complètement sur le côté pour que votre profil soit bien visible, puis reprenez la photo.",
+			SubTaskErrorCodeBodyIsNotFull:              "Assurez-vous que votre corps est entièrement visible de la tête aux pieds, puis reprenez la photo.",
+		},
+		"es": {
+			SubTaskErrorCodeUnknown:                    "Algo salió mal. Vuelve a tomar las fotos e inténtalo de nuevo.",
+			SubTaskErrorCodeWrongPose:                  "Ponte recto, con los brazos ligeramente separados del cuerpo y los pies a la anchura de los hombros, y vuelve a tomar la foto.",
+			SubTaskErrorCodeHumanBodyNotDetected:       "No pudimos encontrarte en la foto. Colócate delante de un fondo liso con todo el cuerpo dentro del encuadre.",
+			SubTaskErrorCodeObjectIsNotHuman:           "Toma una foto de una persona, no de un objeto ni de una imagen.",
+			SubTaskErrorCodeSidePhotoInTheFront:        "Mira directamente a la cámara en la foto frontal.",
+			SubTaskErrorCodeFrontPhotoInTheSide:        "Gira 90 grados hacia un lado para la foto lateral.",
+			SubTaskErrorCodeDetermineLookingSideFailed: "Gírate completamente de lado para que tu perfil se vea con claridad y vuelve a tomar la foto.",
+			SubTaskErrorCodeBodyIsNotFull:              "Asegúrate de que todo tu cuerpo se vea de la cabeza a los pies y vuelve a tomar la foto.",
+		},
+	}
+)
+
+// RegisterMessageCatalog registers the end-user messages of the language, e.g. "it" or "pt-BR".
+// The messages override the registered ones of the same codes, so it can also be used to customize the built-in messages.
+func RegisterMessageCatalog(lang string, catalog MessageCatalog) {
+	messageCatalogsMu.Lock()
+	defer messageCatalogsMu.Unlock()
+
+	lang = normalizeLanguage(lang)
+	merged := MessageCatalog{}
+	for code, message := range messageCatalogs[lang] {
+		merged[code] = message
+	}
+	for code, message := range catalog {
+		merged[code] = message
+	}
+	messageCatalogs[lang] = merged
+}
+
+// UserMessage returns the actionable retake instruction of the failed sub task for the end user in the language.
+// The language is a BCP 47 tag such as "ja" or "de-DE", and it falls back to the base language and then English.
+// It returns empty string when the sub task is not failed.
+func (s *SubTask) UserMessage(lang string) string {
+	if !s.IsFailed() {
+		return ""
+	}
+	return userMessage(lang, s.ErrorCode())
+}
+
+func userMessage(lang string, code SubTaskErrorCode) string {
+	messageCatalogsMu.RLock()
+	defer messageCatalogsMu.RUnlock()
+
+	lang = normalizeLanguage(lang)
+	base, _, _ := strings.Cut(lang, "-")
+	for _, l := range []string{lang, base, DefaultLanguage} {
+		if message, ok := messageCatalogs[l][code]; ok {
+			return message
+		}
+	}
+	return messageCatalogs[DefaultLanguage][SubTaskErrorCodeUnknown]
+}
+
+func normalizeLanguage(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
@@ -0,0 +1,72 @@
+package saia
+
+import "testing"
+
+func TestSubTask_UserMessage(t *testing.T) {
+	t.Parallel()
+
+	RegisterMessageCatalog("x-test", MessageCatalog{SubTaskErrorCodeWrongPose: "custom wrong pose"})
+
+	wrongPose := &SubTask{Name: SubTaskNameFrontSkeletonProcessing, Status: TaskStatusFailure, Message: "The pose is wrong"}
+	tests := []struct {
+		name    string
+		subTask *SubTask
+		lang    string
+		want    string
+	}{
+		{
+			name:    "Not failed",
+			subTask: &SubTask{Status: TaskStatusSuccess},
+			lang:    "en",
+			want:    "",
+		},
+		{
+			name:    "Japanese",
+			subTask: wrongPose,
+			lang:    "ja",
+			want:    "まっすぐに立ち、腕を体から少し離し、足を肩幅に開いて撮り直してください。",
+		},
+		{
+			name:    "Fallback to base language",
+			subTask: &SubTask{Status: TaskStatusFailure, Message: "The body is not full"},
+			lang:    "de_AT",
+			want:    "Achten Sie darauf, dass Ihr ganzer Körper von Kopf bis Fuß sichtbar ist, und nehmen Sie das Foto erneut auf.",
+		},
+		{
+			name:    "Fallback to English",
+			subTask: &SubTask{Status: TaskStatusFailure, Message: "Front photo in the side"},
+			lang:    "x-test",
+			want:    "Turn 90 degrees to your side for the side photo.",
+		},
+		{
+			name:    "Custom catalog",
+			subTask: wrongPose,
+			lang:    "x-test",
+			want:    "custom wrong pose",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.subTask.UserMessage(tt.lang); got != tt.want {
+				t.Errorf("UserMessage(%q) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageCatalogs_Complete(t *testing.T) {
+	t.Parallel()
+
+	messageCatalogsMu.RLock()
+	defer messageCatalogsMu.RUnlock()
+	for _, lang := range []string{"en", "ja", "de", "fr", "es"} {
+		for code := SubTaskErrorCodeUnknown; code <= SubTaskErrorCodeBodyIsNotFull; code++ {
+			if _, ok := messageCatalogs[lang][code]; !ok {
+				t.Errorf("message of %d is not translated into %s", code, lang)
+			}
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package saia
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PersonLimits is the range of the height and weight of the person accepted by the client-side validation.
+type PersonLimits struct {
+	// MinHeight and MaxHeight are in cm
+	MinHeight int
+	MaxHeight int
+	// MinWeight and MaxWeight are in kg, the weight must be greater than 0 regardless of them.
+	// MaxWeight 0 means no upper limit.
+	MinWeight float64
+	MaxWeight float64
+}
+
+// DefaultPersonLimits returns the limits which the API is known to enforce.
+// The height range follows the error message of the API, "This field must be an number between 150 and 230",
+// and the weight only has to be positive. Set stricter ranges with WithPersonLimits.
+func DefaultPersonLimits() PersonLimits {
+	return PersonLimits{
+		MinHeight: 150,
+		MaxHeight: 230,
+	}
+}
+
+// FieldError is the invalid value of a field of the params.
+type FieldError struct {
+	// Field is the name of the field of the params struct, e.g. "Height"
+	Field   string
+	Value   any
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError is the error of the params which are rejected before sending the request.
+// Use errors.As to get the invalid fields.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	s := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		s[i] = err.Error()
+	}
+	return "invalid params: " + strings.Join(s, ", ")
+}
+
+// Field returns the error of the field, it returns nil when the field is valid.
+func (e *ValidationError) Field(name string) *FieldError {
+	for _, err := range e.Errors {
+		if err.Field == name {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldErrors collects the field errors of the params.
+type fieldErrors []*FieldError
+
+func (f *fieldErrors) add(field string, value any, format string, a ...any) {
+	*f = append(*f, &FieldError{Field: field, Value: value, Message: fmt.Sprintf(format, a...)})
+}
+
+func (f *fieldErrors) required(field string, value any, ok bool) {
+	if !ok {
+		f.add(field, value, "is required")
+	}
+}
+
+func (f *fieldErrors) between(field string, value, min, max float64) {
+	if !(value >= min && value <= max) {
+		f.add(field, value, "must be between %v and %v", min, max)
+	}
+}
+
+func (f *fieldErrors) oneOf(field string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	f.add(field, value, "must be one of %s", strings.Join(allowed, ", "))
+}
+
+func (f *fieldErrors) err() error {
+	if len(*f) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: *f}
+}
+
+func (f *fieldErrors) person(gender Gender, height int, weight float64, limits PersonLimits) {
+	f.oneOf("Gender", string(gender), string(GenderMale), string(GenderFemale))
+	f.between("Height", float64(height), float64(limits.MinHeight), float64(limits.MaxHeight))
+	switch {
+	case !(weight > 0):
+		f.add("Weight", weight, "must be greater than 0")
+	case weight < limits.MinWeight:
+		f.add("Weight", weight, "must be %v or greater", limits.MinWeight)
+	case limits.MaxWeight > 0 && weight > limits.MaxWeight:
+		f.add("Weight", weight, "must be %v or less", limits.MaxWeight)
+	}
+}
+
+func (f *fieldErrors) photoFlowType(flow PhotoFlowType, required bool) {
+	if flow == "" && !required {
+		return
+	}
+	f.oneOf("PhotoFlowType", string(flow), string(PhotoFlowTypeFriend), string(PhotoFlowTypeHand))
+}
+
+func (f *fieldErrors) deviceCoordinates(c *DeviceCoordinates) {
+	if c == nil {
+		return
+	}
+	if err := c.Validate(); err != nil {
+		f.add("DeviceCoordinates", c, "%v", strings.ReplaceAll(err.Error(), "\n", ", "))
+	}
+}
+
+func (f *fieldErrors) measurementsType(m MeasurementsType) {
+	if m == "" {
+		return
+	}
+	for _, t := range strings.Split(string(m), ",") {
+		switch MeasurementsType(t) {
+		case MeasurementsTypeAll, MeasurementsTypeFront, MeasurementsTypeVolume:
+		default:
+			f.add("MeasurementsType", m, "must be a combination of %s, %s and %s", MeasurementsTypeAll, MeasurementsTypeFront, MeasurementsTypeVolume)
+			return
+		}
+	}
+}
+
+func (f *fieldErrors) page(page, pageSize int) {
+	if page < 1 {
+		f.add("Page", page, "must be 1 or greater")
+	}
+	if pageSize < 1 {
+		f.add("PageSize", pageSize, "must be 1 or greater")
+	}
+}
+
+func (f *fieldErrors) notificationMethod(method NotificationMethod) {
+	if method == "" {
+		return
+	}
+	f.oneOf("NotificationMethod", string(method), string(NotificationMethodEmail), string(NotificationMethodSMS))
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (c *CreatePersonParams) Validate() error {
+	return c.ValidateWithLimits(DefaultPersonLimits())
+}
+
+// ValidateWithLimits is Validate with the limits of the person instead of the defaults.
+func (c *CreatePersonParams) ValidateWithLimits(limits PersonLimits) error {
+	var f fieldErrors
+	f.person(c.Gender, c.Height, c.Weight, limits)
+	f.measurementsType(c.MeasurementsType)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (c *CreatePersonWithImagesParams) Validate() error {
+	return c.ValidateWithLimits(DefaultPersonLimits())
+}
+
+// ValidateWithLimits is Validate with the limits of the person instead of the defaults.
+func (c *CreatePersonWithImagesParams) ValidateWithLimits(limits PersonLimits) error {
+	var f fieldErrors
+	f.person(c.Gender, c.Height, c.Weight, limits)
+	f.required("FrontImage", c.FrontImage, c.FrontImage != nil)
+	f.required("SideImage", c.SideImage, c.SideImage != nil)
+	f.deviceCoordinates(c.DeviceCoordinates)
+	f.photoFlowType(c.PhotoFlowType, true)
+	f.measurementsType(c.MeasurementsType)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (p *PartialUpdatePersonParams) Validate() error {
+	var f fieldErrors
+	f.deviceCoordinates(p.DeviceCoordinates)
+	f.photoFlowType(p.PhotoFlowType, false)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (s *StartCalculationParams) Validate() error {
+	var f fieldErrors
+	f.measurementsType(s.MeasurementsType)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (l *ListPersonsParams) Validate() error {
+	var f fieldErrors
+	f.page(l.Page, l.PageSize)
+	if l.Gender != nil {
+		f.oneOf("Gender", string(*l.Gender), string(GenderMale), string(GenderFemale))
+	}
+	if l.PhotoFlow != nil {
+		f.oneOf("PhotoFlow", string(*l.PhotoFlow), string(PhotoFlowTypeFriend), string(PhotoFlowTypeHand))
+	}
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (g *GetMeasurementListParams) Validate() error {
+	var f fieldErrors
+	f.page(g.Page, g.PageSize)
+	if g.PersonGender != nil {
+		f.oneOf("PersonGender", string(*g.PersonGender), string(GenderMale), string(GenderFemale))
+	}
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (c *CreateMeasurementParams) Validate() error {
+	var f fieldErrors
+	if c.MtmClientID == 0 && c.Email == "" && c.Phone == "" {
+		f.add("MtmClientID", c.MtmClientID, "either mtm client id, email or phone is required")
+	}
+	if c.Unit != "" {
+		f.oneOf("Unit", string(c.Unit), string(MeasurementUnitCentimeter), string(MeasurementUnitInch))
+	}
+	f.notificationMethod(c.NotificationMethod)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (r *ResendMeasurementLinkParams) Validate() error {
+	var f fieldErrors
+	f.notificationMethod(r.NotificationMethod)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (g *GetMtmClientListParams) Validate() error {
+	var f fieldErrors
+	f.page(g.Page, g.PageSize)
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (c *CreateMtmClientParams) Validate() error {
+	var f fieldErrors
+	f.required("FirstName", c.FirstName, c.FirstName != "")
+	f.required("LastName", c.LastName, c.LastName != "")
+	return f.err()
+}
+
+// Validate returns *ValidationError when the params are rejected by SAIA.
+func (u *UpdateMtmClientParams) Validate() error {
+	var f fieldErrors
+	if u.FirstName != nil {
+		f.required("FirstName", *u.FirstName, *u.FirstName != "")
+	}
+	if u.LastName != nil {
+		f.required("LastName", *u.LastName, *u.LastName != "")
+	}
+	return f.err()
+}
+
+type validator interface {
+	Validate() error
+}
+
+// personValidator is the params of the person validated with the limits of the client.
+type personValidator interface {
+	ValidateWithLimits(limits PersonLimits) error
+}
+
+// validate validates the params unless the validation is disabled by the client option.
+func (a *apiClient) validate(params validator) error {
+	if a.validationDisabled {
+		return nil
+	}
+	if p, ok := params.(personValidator); ok && a.personLimits != nil {
+		return p.ValidateWithLimits(*a.personLimits)
+	}
+	return params.Validate()
+}
@@ -0,0 +1,219 @@
+package saia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreatePersonWithImagesParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *CreatePersonWithImagesParams {
+		return &CreatePersonWithImagesParams{
+			Gender:        GenderMale,
+			Height:        180,
+			Weight:        75,
+			FrontImage:    bytes.NewReader([]byte("front")),
+			SideImage:     bytes.NewReader([]byte("side")),
+			PhotoFlowType: PhotoFlowTypeHand,
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(p *CreatePersonWithImagesParams)
+		want   []string
+	}{
+		{
+			name:   "Valid",
+			modify: func(p *CreatePersonWithImagesParams) {},
+		},
+		{
+			name: "Limits",
+			modify: func(p *CreatePersonWithImagesParams) {
+				p.Height = DefaultPersonLimits().MaxHeight
+				p.Weight = 0.1
+				p.MeasurementsType = MeasurementsTypes(MeasurementsTypeFront, MeasurementsTypeVolume)
+			},
+		},
+		{
+			name: "Zero values",
+			modify: func(p *CreatePersonWithImagesParams) {
+				*p = CreatePersonWithImagesParams{}
+			},
+			want: []string{"Gender", "Height", "Weight", "FrontImage", "SideImage", "PhotoFlowType"},
+		},
+		{
+			name: "Out of range",
+			modify: func(p *CreatePersonWithImagesParams) {
+				p.Gender = "other"
+				p.Height = 149
+				p.Weight = -1
+				p.DeviceCoordinates = &DeviceCoordinates{FrontPhoto: &DeviceCoordinate{BetaX: 90, GammaY: 100}}
+				p.MeasurementsType = "front,girth"
+			},
+			want: []string{"Gender", "Height", "Weight", "DeviceCoordinates", "MeasurementsType"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid()
+			tt.modify(params)
+			if diff := cmp.Diff(invalidFields(t, params.Validate()), tt.want); diff != "" {
+				t.Errorf("Validate() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params validator
+		want   []string
+	}{
+		{
+			name:   "CreatePersonParams",
+			params: &CreatePersonParams{Gender: GenderFemale, Height: 0, Weight: 60},
+			want:   []string{"Height"},
+		},
+		{
+			name:   "PartialUpdatePersonParams without photo flow",
+			params: &PartialUpdatePersonParams{FrontImage: bytes.NewReader(nil)},
+		},
+		{
+			name:   "ListPersonsParams",
+			params: &ListPersonsParams{Page: 0, PageSize: 20},
+			want:   []string{"Page"},
+		},
+		{
+			name:   "CreateMeasurementParams without recipient",
+			params: &CreateMeasurementParams{Unit: "mm"},
+			want:   []string{"MtmClientID", "Unit"},
+		},
+		{
+			name:   "ResendMeasurementLinkParams",
+			params: &ResendMeasurementLinkParams{NotificationMethod: NotificationMethodSMS},
+		},
+		{
+			name:   "CreateMtmClientParams",
+			params: &CreateMtmClientParams{FirstName: "Taro"},
+			want:   []string{"LastName"},
+		},
+		{
+			name:   "UpdateMtmClientParams",
+			params: &UpdateMtmClientParams{FirstName: new(string)},
+			want:   []string{"FirstName"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(invalidFields(t, tt.params.Validate()), tt.want); diff != "" {
+				t.Errorf("Validate() mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_personAPI_CreatePerson_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		validationDisabled bool
+		wantRequests       int64
+	}{
+		{name: "Enabled", wantRequests: 0},
+		{name: "Disabled", validationDisabled: true, wantRequests: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int64
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+			t.Cleanup(s.Close)
+			opts := newDefaultClientOptions()
+			opts.APIHost = s.URL
+			opts.ValidationDisabled = tt.validationDisabled
+			m := &personAPI{newAPIClient(opts)}
+
+			_, err := m.CreatePerson(context.Background(), &CreatePersonParams{Gender: GenderMale, Height: 180, Weight: -1})
+			if err == nil {
+				t.Fatal("CreatePerson() error = nil, want error")
+			}
+			var verr *ValidationError
+			if got := errors.As(err, &verr); got == tt.validationDisabled {
+				t.Errorf("CreatePerson() error = %v, want ValidationError %v", err, !tt.validationDisabled)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func Test_personAPI_CreatePerson_PersonLimits(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	t.Cleanup(s.Close)
+	opts := newDefaultClientOptions()
+	opts.APIHost = s.URL
+	WithPersonLimits(PersonLimits{MinHeight: 100, MaxHeight: 250, MinWeight: 10, MaxWeight: 300}).apply(opts)
+	m := &personAPI{newAPIClient(opts)}
+
+	params := &CreatePersonParams{Gender: GenderMale, Height: 120, Weight: 250}
+	if diff := cmp.Diff(invalidFields(t, params.Validate()), []string{"Height"}); diff != "" {
+		t.Errorf("Validate() mismatch (-got, +want):\n%s", diff)
+	}
+	stricter := PersonLimits{MinHeight: 100, MaxHeight: 250, MinWeight: 30, MaxWeight: 200}
+	if diff := cmp.Diff(invalidFields(t, params.ValidateWithLimits(stricter)), []string{"Weight"}); diff != "" {
+		t.Errorf("ValidateWithLimits() mismatch (-got, +want):\n%s", diff)
+	}
+	if _, err := m.CreatePerson(context.Background(), params); err != nil {
+		t.Fatalf("CreatePerson() error = %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *ValidationError", err)
+	}
+	fields := make([]string, len(verr.Errors))
+	for i, e := range verr.Errors {
+		fields[i] = e.Field
+	}
+	return fields
+}
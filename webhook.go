@@ -0,0 +1,241 @@
+package saia
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookSignatureHeader is the header which has the HMAC-SHA256 signature of the payload
+// in the form of "sha256=<hex digest>".
+const WebhookSignatureHeader = "X-Saia-Signature"
+
+const maxWebhookPayloadBytes = 10 << 20
+
+// DefaultWebhookTolerance is the default max difference between the created time of the payload and the current time.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeTaskSetCompleted         WebhookEventType = "task_set.completed"
+	WebhookEventTypeTaskSetFailed            WebhookEventType = "task_set.failed"
+	WebhookEventTypeMeasurementStatusChanged WebhookEventType = "measurement.status_changed"
+	WebhookEventTypeWidgetFlowStatusChanged  WebhookEventType = "widget_flow.status_changed"
+)
+
+// WebhookPayload is the payload of the webhook callback, the JSON body of the POST request, e.g.
+//
+//	{"event": "task_set.completed", "created": "2023-04-01T00:00:00Z", "data": {"task_set_id": "...", "person_id": 1}}
+//
+// Created is the RFC 3339 time when the payload is sent, and Data is the event of the type of Event.
+// The body is signed with WebhookSignatureHeader, see SignWebhookPayload.
+type WebhookPayload struct {
+	Event   WebhookEventType `json:"event"`
+	Created time.Time        `json:"created"`
+	Data    json.RawMessage  `json:"data"`
+}
+
+// TaskSetEvent is sent when the calculation of the task set is finished.
+// Person is set when the task set is completed, and TaskSet is set when it's failed.
+type TaskSetEvent struct {
+	TaskSetID string   `json:"task_set_id"`
+	PersonID  int      `json:"person_id"`
+	TaskSet   *TaskSet `json:"task_set"`
+	Person    *Person  `json:"person"`
+}
+
+// MeasurementStatusEvent is sent when the status of the measurement is changed.
+type MeasurementStatusEvent struct {
+	MeasurementID  int               `json:"measurement_id"`
+	UUID           string            `json:"uuid"`
+	Status         MeasurementStatus `json:"status"`
+	PreviousStatus MeasurementStatus `json:"previous_status"`
+}
+
+// WidgetFlowStatusEvent is sent when the customer proceeds the widget flow of the measurement.
+type WidgetFlowStatusEvent struct {
+	MeasurementID            int    `json:"measurement_id"`
+	UUID                     string `json:"uuid"`
+	WidgetFlowStatus         string `json:"widget_flow_status"`
+	PreviousWidgetFlowStatus string `json:"previous_widget_flow_status"`
+}
+
+// WebhookHandler is a http.Handler to receive webhook callbacks from SAIA and the widget.
+// It verifies the signature of the payload and dispatches the event to the registered callbacks.
+// It responds with 500 when a callback returns error so that the callback is retried by the sender.
+// Payloads created outside of the tolerance are rejected to prevent the captured requests from being replayed.
+//
+// It responds with 401 when the X-Saia-Signature header doesn't match the body or the payload is outside of the tolerance,
+// and with 400 when the body isn't a WebhookPayload, e.g. created is missing while the tolerance is enabled.
+type WebhookHandler struct {
+	secret    []byte
+	tolerance time.Duration
+
+	mu                       sync.RWMutex
+	taskSetCompletedHandlers []func(r *http.Request, event *TaskSetEvent) error
+	taskSetFailedHandlers    []func(r *http.Request, event *TaskSetEvent) error
+	measurementHandlers      []func(r *http.Request, event *MeasurementStatusEvent) error
+	widgetFlowHandlers       []func(r *http.Request, event *WidgetFlowStatusEvent) error
+}
+
+// WebhookHandlerOption is a option to change webhook handler configuration.
+type WebhookHandlerOption func(*WebhookHandler)
+
+// WebhookHandlerOptionTolerance sets the max difference between the created time of the payload and the current time,
+// DefaultWebhookTolerance by default. The check is disabled when it's 0.
+func WebhookHandlerOptionTolerance(tolerance time.Duration) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.tolerance = tolerance
+	}
+}
+
+// NewWebhookHandler creates a new WebhookHandler with the shared secret used to sign payloads.
+func NewWebhookHandler(secret string, options ...WebhookHandlerOption) *WebhookHandler {
+	h := &WebhookHandler{
+		secret:    []byte(secret),
+		tolerance: DefaultWebhookTolerance,
+	}
+	for _, opt := range options {
+		opt(h)
+	}
+	return h
+}
+
+func (h *WebhookHandler) OnTaskSetCompleted(f func(r *http.Request, event *TaskSetEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.taskSetCompletedHandlers = append(h.taskSetCompletedHandlers, f)
+}
+
+func (h *WebhookHandler) OnTaskSetFailed(f func(r *http.Request, event *TaskSetEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.taskSetFailedHandlers = append(h.taskSetFailedHandlers, f)
+}
+
+func (h *WebhookHandler) OnMeasurementStatusChanged(f func(r *http.Request, event *MeasurementStatusEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.measurementHandlers = append(h.measurementHandlers, f)
+}
+
+func (h *WebhookHandler) OnWidgetFlowStatusChanged(f func(r *http.Request, event *WidgetFlowStatusEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.widgetFlowHandlers = append(h.widgetFlowHandlers, f)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayloadBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if !VerifyWebhookSignature(string(h.secret), body, r.Header.Get(WebhookSignatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	// the created time is covered by the signature, so it can't be changed by the replaying request
+	if h.tolerance > 0 {
+		if payload.Created.IsZero() {
+			http.Error(w, "created of the payload is required", http.StatusBadRequest)
+			return
+		}
+		if d := time.Since(payload.Created); d > h.tolerance || d < -h.tolerance {
+			http.Error(w, "payload is outside of the tolerance", http.StatusUnauthorized)
+			return
+		}
+	}
+	if err := h.dispatch(r, &payload); err != nil {
+		var payloadErr *webhookPayloadError
+		if errors.As(err, &payloadErr) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type webhookPayloadError struct {
+	err error
+}
+
+func (e *webhookPayloadError) Error() string {
+	return fmt.Sprintf("invalid event data: %v", e.err)
+}
+
+func (h *WebhookHandler) dispatch(r *http.Request, payload *WebhookPayload) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch payload.Event {
+	case WebhookEventTypeTaskSetCompleted:
+		return dispatchWebhookEvent(r, payload.Data, h.taskSetCompletedHandlers)
+	case WebhookEventTypeTaskSetFailed:
+		return dispatchWebhookEvent(r, payload.Data, h.taskSetFailedHandlers)
+	case WebhookEventTypeMeasurementStatusChanged:
+		return dispatchWebhookEvent(r, payload.Data, h.measurementHandlers)
+	case WebhookEventTypeWidgetFlowStatusChanged:
+		return dispatchWebhookEvent(r, payload.Data, h.widgetFlowHandlers)
+	default:
+		// unknown events are ignored to be compatible with the events added in the future
+		return nil
+	}
+}
+
+func dispatchWebhookEvent[T any](r *http.Request, data json.RawMessage, handlers []func(r *http.Request, event *T) error) error {
+	var event T
+	if err := json.Unmarshal(data, &event); err != nil {
+		return &webhookPayloadError{err: err}
+	}
+	for _, f := range handlers {
+		if err := f(r, &event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the signature of the payload to be set to WebhookSignatureHeader.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether the signature is valid for the payload.
+func VerifyWebhookSignature(secret string, payload []byte, signature string) bool {
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,183 @@
+package saia_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shing-dev/saia-go"
+	"github.com/shing-dev/saia-go/saiatest"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	const secret = "secret"
+
+	tests := []struct {
+		name       string
+		req        func() *http.Request
+		handlerErr error
+		wantStatus int
+		wantEvents []any
+	}{
+		{
+			name: "Task set completed",
+			req: func() *http.Request {
+				return saiatest.NewWebhookRequest(secret, saia.WebhookEventTypeTaskSetCompleted, &saia.TaskSetEvent{
+					TaskSetID: "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+					PersonID:  1,
+					Person:    &saia.Person{ID: 1},
+				})
+			},
+			wantStatus: http.StatusNoContent,
+			wantEvents: []any{&saia.TaskSetEvent{
+				TaskSetID: "4d563d3f-38ae-4b51-8eab-2b78483b153e",
+				PersonID:  1,
+				Person:    &saia.Person{ID: 1},
+			}},
+		},
+		{
+			name: "Measurement status changed",
+			req: func() *http.Request {
+				return saiatest.NewWebhookRequest(secret, saia.WebhookEventTypeMeasurementStatusChanged, &saia.MeasurementStatusEvent{
+					MeasurementID:  2,
+					Status:         saia.MeasurementStatusSuccess,
+					PreviousStatus: saia.MeasurementStatusPending,
+				})
+			},
+			wantStatus: http.StatusNoContent,
+			wantEvents: []any{&saia.MeasurementStatusEvent{
+				MeasurementID:  2,
+				Status:         saia.MeasurementStatusSuccess,
+				PreviousStatus: saia.MeasurementStatusPending,
+			}},
+		},
+		{
+			name: "Unknown event is ignored",
+			req: func() *http.Request {
+				return saiatest.NewWebhookRequest(secret, "unknown", struct{}{})
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Invalid signature",
+			req: func() *http.Request {
+				return saiatest.NewWebhookRequest("wrong secret", saia.WebhookEventTypeTaskSetFailed, &saia.TaskSetEvent{})
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Replayed payload",
+			req: func() *http.Request {
+				return newWebhookRequestCreatedAt(t, secret, time.Now().Add(-saia.DefaultWebhookTolerance-time.Minute))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Payload created in the future",
+			req: func() *http.Request {
+				return newWebhookRequestCreatedAt(t, secret, time.Now().Add(saia.DefaultWebhookTolerance+time.Minute))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Payload without created",
+			req: func() *http.Request {
+				return newWebhookRequestCreatedAt(t, secret, time.Time{})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid event data",
+			req: func() *http.Request {
+				return saiatest.NewWebhookRequest(secret, saia.WebhookEventTypeWidgetFlowStatusChanged, "data")
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Callback error",
+			req: func() *http.Request {
+				return saiatest.NewWebhookRequest(secret, saia.WebhookEventTypeTaskSetFailed, &saia.TaskSetEvent{})
+			},
+			handlerErr: errors.New("error"),
+			wantStatus: http.StatusInternalServerError,
+			wantEvents: []any{&saia.TaskSetEvent{}},
+		},
+		{
+			name: "GET method",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/", strings.NewReader(""))
+			},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var events []any
+			h := saia.NewWebhookHandler(secret)
+			h.OnTaskSetCompleted(func(r *http.Request, event *saia.TaskSetEvent) error {
+				events = append(events, event)
+				return tt.handlerErr
+			})
+			h.OnTaskSetFailed(func(r *http.Request, event *saia.TaskSetEvent) error {
+				events = append(events, event)
+				return tt.handlerErr
+			})
+			h.OnMeasurementStatusChanged(func(r *http.Request, event *saia.MeasurementStatusEvent) error {
+				events = append(events, event)
+				return tt.handlerErr
+			})
+			h.OnWidgetFlowStatusChanged(func(r *http.Request, event *saia.WidgetFlowStatusEvent) error {
+				events = append(events, event)
+				return tt.handlerErr
+			})
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, tt.req())
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if diff := cmp.Diff(events, tt.wantEvents); diff != "" {
+				t.Errorf("ServeHTTP() events (-got, +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_ToleranceDisabled(t *testing.T) {
+	t.Parallel()
+
+	const secret = "secret"
+	h := saia.NewWebhookHandler(secret, saia.WebhookHandlerOptionTolerance(0))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newWebhookRequestCreatedAt(t, secret, time.Now().Add(-24*time.Hour)))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func newWebhookRequestCreatedAt(t *testing.T, secret string, created time.Time) *http.Request {
+	t.Helper()
+
+	payload, err := json.Marshal(&saia.WebhookPayload{
+		Event:   saia.WebhookEventTypeTaskSetFailed,
+		Created: created,
+		Data:    json.RawMessage(`{}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+	req.Header.Set(saia.WebhookSignatureHeader, saia.SignWebhookPayload(secret, payload))
+	return req
+}